@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/moremoneymod/pr-reviewer/internal/app"
 	"github.com/moremoneymod/pr-reviewer/internal/config"
@@ -21,7 +22,16 @@ func main() {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	application := app.New(ctx, log, cfg.PGConfig.DSN(), cfg.HTTPConfig)
+	application := app.New(
+		ctx, log, cfg.PGConfig.DSN(), cfg.HTTPConfig, cfg.ReviewerConfig,
+		cfg.GitHubConfig, cfg.GitLabConfig, cfg.GiteaConfig, cfg.NotifierConfig, cfg.SMTPConfig,
+		cfg.AuthConfig, cfg.OIDCConfig,
+	)
+	go application.ReviewRequestWorker.Run(ctx)
+	go application.EventWorker.Run(ctx)
+	go application.NotificationWorker.Run(ctx)
+	go application.OverdueWorker.Run(ctx)
+	go application.DigestWorker.Run(ctx)
 	application.HTTPSrv.MustRun()
 
 }
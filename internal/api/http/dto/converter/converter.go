@@ -14,10 +14,60 @@ func ToDomainTeamFromDTO(teamDTO request.TeamRequest) *domain.Team {
 		members[i] = ToDomainMemberFromDTO(member)
 	}
 
-	return &domain.Team{
-		Name:    teamDTO.TeamName,
-		Members: members,
+	team := &domain.Team{
+		Name:       teamDTO.TeamName,
+		ParentName: teamDTO.ParentTeam,
+		Members:    members,
 	}
+	if teamDTO.Settings != nil {
+		team.Settings = ToDomainTeamSettingsFromDTO(*teamDTO.Settings)
+	}
+
+	return team
+}
+
+func ToDomainTeamSettingsFromDTO(settingsDTO request.TeamSettingsRequest) domain.TeamSettings {
+	var settings domain.TeamSettings
+
+	if settingsDTO.ReviewSLAMinutes != nil {
+		sla := time.Duration(*settingsDTO.ReviewSLAMinutes) * time.Minute
+		settings.ReviewSLA = &sla
+	}
+	if settingsDTO.ReviewerFallback != nil {
+		fallback := domain.ReviewerFallback(*settingsDTO.ReviewerFallback)
+		settings.ReviewerFallback = &fallback
+	}
+
+	return settings
+}
+
+func ToDomainWorkingHoursFromDTO(workingHoursDTO request.UserWorkingHoursRequest) domain.WorkingHours {
+	return domain.WorkingHours{
+		Timezone:  workingHoursDTO.Timezone,
+		StartHour: workingHoursDTO.WorkStart,
+		EndHour:   workingHoursDTO.WorkEnd,
+	}
+}
+
+func ToDomainTeamUpdateFromDTO(updateDTO request.TeamUpdateRequest) domain.TeamUpdate {
+	addMembers := make([]domain.Member, len(updateDTO.AddMembers))
+	for i, member := range updateDTO.AddMembers {
+		addMembers[i] = ToDomainMemberFromDTO(member)
+	}
+
+	update := domain.TeamUpdate{
+		NewName:           updateDTO.TeamName,
+		ParentName:        updateDTO.ParentTeam,
+		AddMembers:        addMembers,
+		RemoveMembers:     updateDTO.RemoveMembers,
+		OpenReviewsPolicy: domain.OpenReviewsPolicy(updateDTO.OpenReviews),
+	}
+	if updateDTO.Settings != nil {
+		settings := ToDomainTeamSettingsFromDTO(*updateDTO.Settings)
+		update.Settings = &settings
+	}
+
+	return update
 }
 
 func ToDomainMemberFromDTO(memberDTO request.TeamMemberRequest) domain.Member {
@@ -30,7 +80,14 @@ func ToDomainMemberFromDTO(memberDTO request.TeamMemberRequest) domain.Member {
 
 func ToDTOTeamFromDomain(teamDomain *domain.Team) response.TeamResponse {
 	team := response.TeamResponse{
-		TeamName: teamDomain.Name,
+		TeamName:      teamDomain.Name,
+		ParentTeam:    teamDomain.ParentName,
+		Settings:      ToDTOTeamSettingsFromDomain(teamDomain.Settings),
+		ExistingUsers: teamDomain.ExistingUsers,
+	}
+	if teamDomain.Effective.ReviewerFallback != nil {
+		effective := ToDTOTeamSettingsFromDomain(teamDomain.Effective)
+		team.EffectiveSettings = &effective
 	}
 
 	team.Members = make([]response.TeamMember, 0, len(teamDomain.Members))
@@ -42,16 +99,76 @@ func ToDTOTeamFromDomain(teamDomain *domain.Team) response.TeamResponse {
 	return team
 }
 
+func ToDTOTeamListFromDomain(pageDomain *domain.TeamPage) response.TeamListResponse {
+	teams := make([]response.TeamSummary, len(pageDomain.Teams))
+	for i, team := range pageDomain.Teams {
+		teams[i] = response.TeamSummary{
+			TeamName:           team.Name,
+			ParentTeam:         team.ParentName,
+			MembersCount:       team.MemberCount,
+			ActiveMembersCount: team.ActiveMemberCount,
+		}
+		for _, member := range team.Members {
+			teams[i].Members = append(teams[i].Members, ToDTOTeamMemberFromDomain(member))
+		}
+	}
+
+	return response.TeamListResponse{
+		Teams:  teams,
+		Total:  pageDomain.Total,
+		Limit:  pageDomain.Limit,
+		Offset: pageDomain.Offset,
+	}
+}
+
+func ToDTOTeamSettingsFromDomain(settingsDomain domain.TeamSettings) response.TeamSettings {
+	var settings response.TeamSettings
+
+	if settingsDomain.ReviewSLA != nil && *settingsDomain.ReviewSLA > 0 {
+		minutes := int(settingsDomain.ReviewSLA.Minutes())
+		settings.ReviewSLAMinutes = &minutes
+	}
+	if settingsDomain.ReviewerFallback != nil {
+		fallback := string(*settingsDomain.ReviewerFallback)
+		settings.ReviewerFallback = &fallback
+	}
+
+	return settings
+}
+
+func ToDTOTeamTreeFromDomain(nodesDomain []*domain.TeamNode) response.TeamTreeResponse {
+	return response.TeamTreeResponse{
+		Teams: ToDTOTeamNodesFromDomain(nodesDomain),
+	}
+}
+
+func ToDTOTeamNodesFromDomain(nodesDomain []*domain.TeamNode) []response.TeamNode {
+	nodes := make([]response.TeamNode, len(nodesDomain))
+	for i, node := range nodesDomain {
+		nodes[i] = response.TeamNode{
+			TeamName:         node.Name,
+			Members:          node.MemberCount,
+			TotalMembers:     node.TotalMemberCount,
+			OpenReviews:      node.OpenReviews,
+			TotalOpenReviews: node.TotalOpenReviews,
+			Children:         ToDTOTeamNodesFromDomain(node.Children),
+		}
+	}
+
+	return nodes
+}
+
 func ToDTOTeamMemberFromDomain(teamMemberDomain domain.Member) response.TeamMember {
 	return response.TeamMember{
 		UserID:   teamMemberDomain.UserID,
 		Username: teamMemberDomain.Username,
+		Teams:    nonNilStrings(teamMemberDomain.TeamNames()),
 		IsActive: teamMemberDomain.IsActive,
 	}
 }
 
 func ToDTOPRFromDomain(PRDomain *domain.PR) response.PRResponse {
-	var createdAtStr, mergedAtStr *string
+	var createdAtStr, mergedAtStr, closedAtStr *string
 
 	if PRDomain.CreatedAt != nil {
 		formatted := PRDomain.CreatedAt.Format(time.RFC3339)
@@ -63,6 +180,11 @@ func ToDTOPRFromDomain(PRDomain *domain.PR) response.PRResponse {
 		mergedAtStr = &formatted
 	}
 
+	if PRDomain.ClosedAt != nil {
+		formatted := PRDomain.ClosedAt.Format(time.RFC3339)
+		closedAtStr = &formatted
+	}
+
 	prReviewers := PRDomain.Reviewers
 	if prReviewers == nil {
 		prReviewers = []string{}
@@ -72,19 +194,89 @@ func ToDTOPRFromDomain(PRDomain *domain.PR) response.PRResponse {
 		PullRequestID:     PRDomain.ID,
 		PullRequestName:   PRDomain.Name,
 		AuthorID:          PRDomain.AuthorID,
+		MergedBy:          PRDomain.MergedBy,
 		Status:            PRStatusToString(PRDomain.Status),
 		AssignedReviewers: prReviewers,
 		CreatedAt:         createdAtStr,
 		MergedAt:          mergedAtStr,
+		ClosedAt:          closedAtStr,
 	}
 }
 
 func ToDTOUserFromDomain(userDomain *domain.User) response.UserResponse {
+	var email *string
+	if userDomain.Email != "" {
+		email = &userDomain.Email
+	}
+
 	return response.UserResponse{
-		UserID:   userDomain.ID,
-		Username: userDomain.Username,
-		TeamName: userDomain.TeamName,
-		IsActive: userDomain.IsActive,
+		UserID:        userDomain.ID,
+		Username:      userDomain.Username,
+		Email:         email,
+		TeamName:      primaryTeamName(userDomain.Teams),
+		Teams:         nonNilStrings(userDomain.TeamNames()),
+		Role:          string(userDomain.Role),
+		LeadOf:        nonNilStrings(userDomain.LedTeamNames()),
+		Timezone:      userDomain.WorkingHours.Timezone,
+		WorkStart:     userDomain.WorkingHours.StartHour,
+		WorkEnd:       userDomain.WorkingHours.EndHour,
+		OpenReviews:   userDomain.OpenReviews,
+		IsActive:      userDomain.IsActive,
+		DigestEnabled: userDomain.DigestEnabled,
+	}
+}
+
+func ToDomainEmailSettingsFromDTO(req request.UserEmailRequest) domain.EmailSettings {
+	return domain.EmailSettings{
+		Email:         req.Email,
+		DigestEnabled: req.DigestEnabled,
+	}
+}
+
+func ToDTOUserDigestFromDomain(digestDomain *domain.Digest) response.UserDigestResponse {
+	return response.UserDigestResponse{
+		UserID:       digestDomain.UserID,
+		Email:        digestDomain.Email,
+		PullRequests: ToDTOPRsShortFromDomain(digestDomain.Reviews),
+	}
+}
+
+func ToDTOUserListFromDomain(usersDomain []*domain.User) response.UserListResponse {
+	users := make([]response.UserResponse, len(usersDomain))
+	for i, user := range usersDomain {
+		users[i] = ToDTOUserFromDomain(user)
+	}
+
+	return response.UserListResponse{Users: users}
+}
+
+func ToDTOTeamMoveFromDomain(moveDomain *domain.TeamMove) response.TeamMoveResponse {
+	reassigned := make([]response.ReassignmentResponse, 0, len(moveDomain.ReassignedReviews))
+	for _, reassignment := range moveDomain.ReassignedReviews {
+		reassigned = append(reassigned, response.ReassignmentResponse{
+			PullRequestID: reassignment.PRID,
+			ReplacedBy:    reassignment.NewReviewerID,
+		})
+	}
+
+	kept := moveDomain.KeptReviews
+	if kept == nil {
+		kept = []string{}
+	}
+
+	unassigned := moveDomain.UnassignedReviews
+	if unassigned == nil {
+		unassigned = []string{}
+	}
+
+	return response.TeamMoveResponse{
+		UserID:            moveDomain.UserID,
+		FromTeam:          moveDomain.FromTeam,
+		ToTeam:            moveDomain.ToTeam,
+		OpenReviews:       string(moveDomain.Policy),
+		KeptReviews:       kept,
+		UnassignedReviews: unassigned,
+		ReassignedReviews: reassigned,
 	}
 }
 
@@ -98,43 +290,369 @@ func ToDTOPRsShortFromDomain(PRsShortDomain []*domain.PRShort) []response.PRShor
 }
 
 func ToDTOPRShortFromDomain(PRShortDomain *domain.PRShort) response.PRShortResponse {
+	var assignedAtStr *string
+	if PRShortDomain.AssignedAt != nil {
+		formatted := PRShortDomain.AssignedAt.Format(time.RFC3339)
+		assignedAtStr = &formatted
+	}
+
 	return response.PRShortResponse{
-		PullRequestID:   PRShortDomain.ID,
-		PullRequestName: PRShortDomain.Name,
-		AuthorID:        PRShortDomain.AuthorID,
-		Status:          PRShortDomain.Status,
+		AssignedAt:           assignedAtStr,
+		PullRequestID:        PRShortDomain.ID,
+		PullRequestName:      PRShortDomain.Name,
+		AuthorID:             PRShortDomain.AuthorID,
+		Status:               PRShortDomain.Status,
+		WaitingBusinessHours: PRShortDomain.BusinessWait.Hours(),
+		Overdue:              PRShortDomain.Overdue,
 	}
 }
 
 func ToDTOStatisticsFromDomain(statisticsDomain *domain.Statistics) response.StatisticsResponse {
-	teamStats := make(map[string]response.TeamStat)
-	for teamName, stat := range statisticsDomain.TeamStats {
-		teamStats[teamName] = response.TeamStat{
-			MemberCount:   stat.MemberCount,
-			ActiveMembers: stat.ActiveMembers,
-			PRCount:       stat.PRCount,
+	userAssignments := make([]response.UserAssignmentStat, 0, len(statisticsDomain.UserAssignments))
+	for _, stat := range statisticsDomain.UserAssignments {
+		userAssignments = append(userAssignments, response.UserAssignmentStat{
+			UserID:            stat.UserID,
+			Username:          stat.Username,
+			TeamName:          stat.TeamName,
+			TotalAssignments:  stat.TotalAssignments,
+			OpenAssignments:   stat.OpenAssignments,
+			MergedAssignments: stat.MergedAssignments,
+		})
+	}
+
+	series := make([]response.StatisticsPoint, len(statisticsDomain.Series))
+	for i, point := range statisticsDomain.Series {
+		series[i] = response.StatisticsPoint{
+			BucketStart: point.Start.Format(time.RFC3339),
+			CreatedPRs:  point.CreatedPRs,
+			MergedPRs:   point.MergedPRs,
+			Assignments: point.Assignments,
 		}
 	}
 
 	return response.StatisticsResponse{
 		Statistics: response.StatisticsData{
+			UserAssignments: userAssignments,
+			Series:          series,
+			Bucket:          string(statisticsDomain.Bucket),
 			TotalPRs:        statisticsDomain.TotalPRs,
 			OpenPRs:         statisticsDomain.OpenPRs,
 			MergedPRs:       statisticsDomain.MergedPRs,
-			UserAssignments: statisticsDomain.UserAssignments,
-			PRAssignments:   statisticsDomain.PRAssignments,
-			TeamStats:       teamStats,
+			TotalTeams:      statisticsDomain.TotalTeams,
+			TotalUsers:      statisticsDomain.TotalUsers,
+			ActiveUsers:     statisticsDomain.ActiveUsers,
 		},
 	}
 }
 
+func ToDTOLatencyStatisticsFromDomain(statisticsDomain *domain.LatencyStatistics) response.LatencyStatisticsResponse {
+	teams := make([]response.TeamLatencyResponse, len(statisticsDomain.Teams))
+	for i, team := range statisticsDomain.Teams {
+		teams[i] = response.TeamLatencyResponse{
+			LatencyMetricsResponse: ToDTOLatencyMetricsFromDomain(team.LatencyMetrics),
+			TeamName:               team.TeamName,
+		}
+	}
+
+	reviewers := make([]response.ReviewerLatencyResponse, len(statisticsDomain.Reviewers))
+	for i, reviewer := range statisticsDomain.Reviewers {
+		reviewers[i] = response.ReviewerLatencyResponse{
+			TimeToFirstReview: ToDTOLatencyFromDomain(reviewer.TimeToFirstReview),
+			UserID:            reviewer.UserID,
+			Username:          reviewer.Username,
+			Assignments:       reviewer.Assignments,
+			Reassignments:     reviewer.Reassignments,
+			ReassignmentRate:  reviewer.ReassignmentRate(),
+		}
+	}
+
+	return response.LatencyStatisticsResponse{
+		From:      statisticsDomain.From.Format(time.RFC3339),
+		To:        statisticsDomain.To.Format(time.RFC3339),
+		Overall:   ToDTOLatencyMetricsFromDomain(statisticsDomain.Overall),
+		Teams:     teams,
+		Reviewers: reviewers,
+	}
+}
+
+func ToDTOFairnessReportFromDomain(reportDomain *domain.FairnessReport) response.FairnessReportResponse {
+	teams := make([]response.TeamFairnessResponse, len(reportDomain.Teams))
+	for i, team := range reportDomain.Teams {
+		reviewers := make([]response.ReviewerFairnessResponse, len(team.Reviewers))
+		for j, reviewer := range team.Reviewers {
+			reviewers[j] = response.ReviewerFairnessResponse{
+				UserID:      reviewer.UserID,
+				Username:    reviewer.Username,
+				Assignments: reviewer.Assignments,
+				Deviation:   reviewer.Deviation,
+			}
+		}
+
+		teams[i] = response.TeamFairnessResponse{
+			TeamName:    team.TeamName,
+			Reviewers:   reviewers,
+			Mean:        team.Mean,
+			Gini:        team.Gini,
+			MaxMinRatio: team.MaxMinRatio,
+		}
+	}
+
+	return response.FairnessReportResponse{
+		From:  reportDomain.From.Format(time.RFC3339),
+		To:    reportDomain.To.Format(time.RFC3339),
+		Teams: teams,
+	}
+}
+
+func ToDTOLatencyMetricsFromDomain(metrics domain.LatencyMetrics) response.LatencyMetricsResponse {
+	return response.LatencyMetricsResponse{
+		TimeToMerge:       ToDTOLatencyFromDomain(metrics.TimeToMerge),
+		TimeToFirstReview: ToDTOLatencyFromDomain(metrics.TimeToFirstReview),
+		Assignments:       metrics.Assignments,
+		Reassignments:     metrics.Reassignments,
+		ReassignmentRate:  metrics.ReassignmentRate(),
+	}
+}
+
+func ToDTOLatencyFromDomain(latency domain.Latency) response.LatencyResponse {
+	return response.LatencyResponse{
+		Count:       latency.Count,
+		MedianHours: latency.Median.Hours(),
+		P90Hours:    latency.P90.Hours(),
+	}
+}
+
+func ToDTOAssignmentLogFromDomain(prId string, decisions []*domain.AssignmentDecision) response.AssignmentLogResponse {
+	entries := make([]response.AssignmentLogEntry, 0, len(decisions))
+	for _, decision := range decisions {
+		entries = append(entries, ToDTOAssignmentLogEntryFromDomain(decision))
+	}
+
+	return response.AssignmentLogResponse{
+		PullRequestID: prId,
+		Entries:       entries,
+	}
+}
+
+func ToDTOPRTimelineFromDomain(prId string, eventsDomain []*domain.PREvent) response.PRTimelineResponse {
+	events := make([]response.PREventResponse, len(eventsDomain))
+	for i, event := range eventsDomain {
+		events[i] = response.PREventResponse{
+			CreatedAt: event.CreatedAt.Format(time.RFC3339),
+			Type:      string(event.Type),
+			Actor:     event.Actor,
+			UserID:    event.UserID,
+			Reason:    event.Reason,
+			Decision:  string(event.Decision),
+		}
+	}
+
+	return response.PRTimelineResponse{
+		PullRequestID: prId,
+		Events:        events,
+	}
+}
+
+func ToDTOAssignmentLogEntryFromDomain(decision *domain.AssignmentDecision) response.AssignmentLogEntry {
+	var createdAtStr *string
+	if decision.CreatedAt != nil {
+		formatted := decision.CreatedAt.Format(time.RFC3339)
+		createdAtStr = &formatted
+	}
+
+	exclusions := make([]response.AssignmentExclusion, 0, len(decision.Exclusions))
+	for _, exclusion := range decision.Exclusions {
+		exclusions = append(exclusions, response.AssignmentExclusion{
+			UserID: exclusion.UserID,
+			Reason: exclusion.Reason,
+		})
+	}
+
+	scores := make([]response.AssignmentScore, 0, len(decision.Scores))
+	for _, score := range decision.Scores {
+		scores = append(scores, response.AssignmentScore{
+			UserID:         score.UserID,
+			Pairings:       score.Pairings,
+			Weight:         score.Weight,
+			InWorkingHours: score.InWorkingHours,
+		})
+	}
+
+	return response.AssignmentLogEntry{
+		CreatedAt:  createdAtStr,
+		Trigger:    decision.Trigger,
+		Strategy:   decision.Strategy,
+		ActorID:    decision.ActorID,
+		Seed:       decision.Seed,
+		Candidates: decision.Candidates,
+		Exclusions: exclusions,
+		Scores:     scores,
+		Chosen:     decision.Chosen,
+	}
+}
+
+func ToDomainSubscriptionFromDTO(subscriptionDTO request.SubscriptionRequest) domain.Subscription {
+	eventTypes := make([]domain.EventType, len(subscriptionDTO.EventTypes))
+	for i, eventType := range subscriptionDTO.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	return domain.Subscription{
+		URL:        subscriptionDTO.URL,
+		Secret:     subscriptionDTO.Secret,
+		EventTypes: eventTypes,
+	}
+}
+
+func ToDTOSubscriptionFromDomain(subscriptionDomain *domain.Subscription) response.SubscriptionResponse {
+	eventTypes := make([]string, len(subscriptionDomain.EventTypes))
+	for i, eventType := range subscriptionDomain.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return response.SubscriptionResponse{
+		CreatedAt:  formatTime(subscriptionDomain.CreatedAt),
+		ID:         subscriptionDomain.ID,
+		URL:        subscriptionDomain.URL,
+		EventTypes: eventTypes,
+	}
+}
+
+func ToDTOSubscriptionListFromDomain(subscriptionsDomain []*domain.Subscription) response.SubscriptionListResponse {
+	subscriptions := make([]response.SubscriptionResponse, len(subscriptionsDomain))
+	for i, subscription := range subscriptionsDomain {
+		subscriptions[i] = ToDTOSubscriptionFromDomain(subscription)
+	}
+
+	return response.SubscriptionListResponse{Subscriptions: subscriptions}
+}
+
+func ToDTOSubscriptionDeliveriesFromDomain(
+	subscriptionId int64,
+	deliveriesDomain []*domain.SubscriptionDelivery,
+) response.SubscriptionDeliveriesResponse {
+	deliveries := make([]response.SubscriptionDelivery, len(deliveriesDomain))
+	for i, delivery := range deliveriesDomain {
+		deliveries[i] = response.SubscriptionDelivery{
+			CreatedAt:     formatTime(delivery.CreatedAt),
+			DeliveredAt:   formatTime(delivery.DeliveredAt),
+			LastError:     delivery.LastError,
+			ID:            delivery.ID,
+			EventID:       delivery.Event.ID,
+			EventType:     string(delivery.Event.Type),
+			PullRequestID: delivery.Event.PRID,
+			Status:        string(delivery.Status),
+			Attempts:      delivery.Attempts,
+		}
+		if delivery.Status == domain.DeliveryStatusPending {
+			deliveries[i].NextAttemptAt = formatTime(&delivery.NextAttemptAt)
+		}
+		if delivery.LastStatusCode != 0 {
+			deliveries[i].LastStatusCode = &delivery.LastStatusCode
+		}
+	}
+
+	return response.SubscriptionDeliveriesResponse{
+		SubscriptionID: subscriptionId,
+		Deliveries:     deliveries,
+	}
+}
+
 func PRStatusToString(status domain.PRStatus) string {
 	switch status {
 	case domain.PRStatusOpen:
 		return "OPEN"
 	case domain.PRStatusMerged:
 		return "MERGED"
+	case domain.PRStatusClosed:
+		return "CLOSED"
 	default:
 		return "OPEN"
 	}
 }
+
+// primaryTeamName keeps the single team_name field meaningful for users in
+// several teams: it is the first membership by name.
+func primaryTeamName(memberships []domain.TeamMembership) string {
+	if len(memberships) == 0 {
+		return ""
+	}
+
+	return memberships[0].TeamName
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func ToDTOAPITokenFromDomain(tokenDomain *domain.APIToken) response.APITokenResponse {
+	scopes := make([]string, len(tokenDomain.Scopes))
+	for i, scope := range tokenDomain.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return response.APITokenResponse{
+		CreatedAt:  formatTime(tokenDomain.CreatedAt),
+		LastUsedAt: formatTime(tokenDomain.LastUsedAt),
+		RevokedAt:  formatTime(tokenDomain.RevokedAt),
+		Token:      tokenDomain.Token,
+		ID:         tokenDomain.ID,
+		Name:       tokenDomain.Name,
+		Prefix:     tokenDomain.Prefix,
+		Scopes:     scopes,
+	}
+}
+
+func ToDTOAPITokenListFromDomain(tokensDomain []*domain.APIToken) response.APITokenListResponse {
+	tokens := make([]response.APITokenResponse, len(tokensDomain))
+	for i, token := range tokensDomain {
+		tokens[i] = ToDTOAPITokenFromDomain(token)
+	}
+
+	return response.APITokenListResponse{Tokens: tokens}
+}
+
+func ToDomainScopesFromDTO(scopesDTO []string) []domain.Scope {
+	scopes := make([]domain.Scope, len(scopesDTO))
+	for i, scope := range scopesDTO {
+		scopes[i] = domain.Scope(scope)
+	}
+
+	return scopes
+}
+
+func ToDTOAuditEventListFromDomain(eventsDomain []*domain.AuditEvent) response.AuditEventListResponse {
+	events := make([]response.AuditEventResponse, len(eventsDomain))
+	for i, event := range eventsDomain {
+		diff := make(map[string]response.AuditChangeResponse, len(event.Diff))
+		for field, change := range event.Diff {
+			diff[field] = response.AuditChangeResponse{Before: change.Before, After: change.After}
+		}
+
+		events[i] = response.AuditEventResponse{
+			Diff:       diff,
+			CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+			Actor:      event.Actor,
+			Action:     event.Action,
+			EntityType: string(event.EntityType),
+			EntityID:   event.EntityID,
+			RequestID:  event.RequestID,
+			ID:         event.ID,
+		}
+	}
+
+	return response.AuditEventListResponse{Events: events}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,28 @@
+package request
+
+// GiteaPullRequestEvent is the subset of a Gitea or Forgejo pull_request
+// webhook payload the service reads.
+type GiteaPullRequestEvent struct {
+	Action      string           `json:"action"`
+	Number      int              `json:"number"`
+	PullRequest GiteaPullRequest `json:"pull_request"`
+	Repository  GiteaRepository  `json:"repository"`
+}
+
+type GiteaPullRequest struct {
+	ID     int64     `json:"id"`
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	User   GiteaUser `json:"user"`
+	State  string    `json:"state"`
+	Draft  bool      `json:"draft"`
+	Merged bool      `json:"merged"`
+}
+
+type GiteaRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type GiteaUser struct {
+	Login string `json:"login"`
+}
@@ -0,0 +1,27 @@
+package request
+
+// GitHubPullRequestEvent is the subset of a GitHub pull_request webhook
+// payload the service reads.
+type GitHubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+	Repository  GitHubRepository  `json:"repository"`
+}
+
+type GitHubPullRequest struct {
+	ID     int64      `json:"id"`
+	Number int        `json:"number"`
+	Title  string     `json:"title"`
+	User   GitHubUser `json:"user"`
+	Draft  bool       `json:"draft"`
+	Merged bool       `json:"merged"`
+}
+
+type GitHubRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type GitHubUser struct {
+	Login string `json:"login"`
+}
@@ -0,0 +1,30 @@
+package request
+
+// GitLabMergeRequestEvent is the subset of a GitLab Merge Request Hook
+// payload the service reads. User is whoever triggered the event.
+type GitLabMergeRequestEvent struct {
+	ObjectKind       string             `json:"object_kind"`
+	User             GitLabUser         `json:"user"`
+	Project          GitLabProject      `json:"project"`
+	ObjectAttributes GitLabMergeRequest `json:"object_attributes"`
+}
+
+type GitLabMergeRequest struct {
+	ID             int64  `json:"id"`
+	IID            int    `json:"iid"`
+	Title          string `json:"title"`
+	AuthorID       int64  `json:"author_id"`
+	State          string `json:"state"`
+	Action         string `json:"action"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+}
+
+type GitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type GitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
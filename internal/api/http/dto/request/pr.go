@@ -4,6 +4,7 @@ type PRCreateRequest struct {
 	PullRequestID   string `json:"pull_request_id" validate:"required,min=1"`
 	PullRequestName string `json:"pull_request_name" validate:"required,min=1"`
 	AuthorID        string `json:"author_id" validate:"required,min=1"`
+	TeamName        string `json:"team_name"`
 }
 
 type PRMergeRequest struct {
@@ -14,3 +15,9 @@ type PRReassignRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required,min=1"`
 	OldUserID     string `json:"old_user_id" validate:"required,min=1"`
 }
+
+type PRReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1"`
+	ReviewerID    string `json:"reviewer_id" validate:"required,min=1"`
+	Decision      string `json:"decision" validate:"required,oneof=approved changes_requested commented"`
+}
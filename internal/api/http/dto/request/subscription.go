@@ -0,0 +1,7 @@
+package request
+
+type SubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=reviewers.assigned reviewer.reassigned reviewer.unassigned pr.merged"`
+}
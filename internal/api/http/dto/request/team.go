@@ -3,8 +3,26 @@ package request
 import _ "github.com/go-playground/validator/v10"
 
 type TeamRequest struct {
-	TeamName string              `json:"team_name" validate:"required"`
-	Members  []TeamMemberRequest `json:"members" validate:"required,min=1,dive"`
+	TeamName   string               `json:"team_name" validate:"required"`
+	ParentTeam string               `json:"parent_team"`
+	Settings   *TeamSettingsRequest `json:"settings"`
+	Members    []TeamMemberRequest  `json:"members" validate:"required,min=1,dive"`
+}
+
+type TeamUpdateRequest struct {
+	TeamName      string               `json:"team_name"`
+	ParentTeam    *string              `json:"parent_team"`
+	Settings      *TeamSettingsRequest `json:"settings"`
+	AddMembers    []TeamMemberRequest  `json:"add_members" validate:"dive"`
+	RemoveMembers []string             `json:"remove_members" validate:"dive,required"`
+	OpenReviews   string               `json:"open_reviews" validate:"omitempty,oneof=reject unassign keep"`
+}
+
+// TeamSettingsRequest holds team settings; omitted fields are inherited from
+// the parent team.
+type TeamSettingsRequest struct {
+	ReviewSLAMinutes *int    `json:"review_sla_minutes" validate:"omitnil,min=1"`
+	ReviewerFallback *string `json:"reviewer_fallback" validate:"omitnil,oneof=none parent"`
 }
 
 type TeamMemberRequest struct {
@@ -0,0 +1,6 @@
+package request
+
+type APITokenRequest struct {
+	Name   string   `json:"name" validate:"required,max=255"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=read write admin"`
+}
@@ -4,3 +4,38 @@ type UserActiveRequest struct {
 	UserID   string `json:"user_id" validate:"required,min=1"`
 	IsActive bool   `json:"is_active" validate:"required"`
 }
+
+type UserWorkingHoursRequest struct {
+	UserID    string `json:"user_id" validate:"required,min=1"`
+	Timezone  string `json:"timezone" validate:"required"`
+	WorkStart int    `json:"work_start" validate:"min=0,max=23"`
+	WorkEnd   int    `json:"work_end" validate:"min=1,max=24"`
+}
+
+type UserEmailRequest struct {
+	UserID        string `json:"user_id" validate:"required,min=1"`
+	Email         string `json:"email" validate:"omitempty,email"`
+	DigestEnabled *bool  `json:"digest_enabled"`
+}
+
+type UserDigestRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1"`
+}
+
+type UserMoveTeamRequest struct {
+	UserID       string `json:"user_id" validate:"required,min=1"`
+	FromTeamName string `json:"from_team_name"`
+	TeamName     string `json:"team_name" validate:"required,min=1"`
+	OpenReviews  string `json:"open_reviews" validate:"omitempty,oneof=reject unassign keep reassign"`
+}
+
+type UserRoleRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1"`
+	Role   string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type UserTeamRoleRequest struct {
+	UserID   string `json:"user_id" validate:"required,min=1"`
+	TeamName string `json:"team_name" validate:"required,min=1"`
+	Role     string `json:"role" validate:"required,oneof=team_lead member"`
+}
@@ -0,0 +1,21 @@
+package response
+
+type AuditChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditEventResponse struct {
+	Diff       map[string]AuditChangeResponse `json:"diff"`
+	CreatedAt  string                         `json:"created_at"`
+	Actor      string                         `json:"actor"`
+	Action     string                         `json:"action"`
+	EntityType string                         `json:"entity_type"`
+	EntityID   string                         `json:"entity_id"`
+	RequestID  string                         `json:"request_id,omitempty"`
+	ID         int64                          `json:"id"`
+}
+
+type AuditEventListResponse struct {
+	Events []AuditEventResponse `json:"events"`
+}
@@ -3,21 +3,69 @@ package response
 type PRResponse struct {
 	CreatedAt         *string  `json:"createdAt,omitempty"`
 	MergedAt          *string  `json:"mergedAt,omitempty"`
+	ClosedAt          *string  `json:"closedAt,omitempty"`
 	PullRequestID     string   `json:"pull_request_id"`
 	PullRequestName   string   `json:"pull_request_name"`
 	AuthorID          string   `json:"author_id"`
+	MergedBy          string   `json:"merged_by,omitempty"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
 }
 
 type PRShortResponse struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"`
+	AssignedAt           *string `json:"assigned_at,omitempty"`
+	PullRequestID        string  `json:"pull_request_id"`
+	PullRequestName      string  `json:"pull_request_name"`
+	AuthorID             string  `json:"author_id"`
+	Status               string  `json:"status"`
+	WaitingBusinessHours float64 `json:"waiting_business_hours"`
+	Overdue              bool    `json:"overdue"`
 }
 
 type PRReassignResponse struct {
 	PullRequest PRResponse `json:"pr"`
 	ReplacedBy  string     `json:"replaced_by"`
 }
+
+type AssignmentLogResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Entries       []AssignmentLogEntry `json:"entries"`
+}
+
+type AssignmentLogEntry struct {
+	CreatedAt  *string               `json:"created_at,omitempty"`
+	Trigger    string                `json:"trigger"`
+	Strategy   string                `json:"strategy"`
+	ActorID    string                `json:"actor_id,omitempty"`
+	Seed       int64                 `json:"seed"`
+	Candidates []string              `json:"candidates"`
+	Exclusions []AssignmentExclusion `json:"exclusions"`
+	Scores     []AssignmentScore     `json:"scores"`
+	Chosen     []string              `json:"chosen"`
+}
+
+type AssignmentExclusion struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type AssignmentScore struct {
+	UserID         string  `json:"user_id"`
+	Pairings       int     `json:"pairings"`
+	Weight         float64 `json:"weight"`
+	InWorkingHours bool    `json:"in_working_hours"`
+}
+
+type PRTimelineResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Events        []PREventResponse `json:"events"`
+}
+
+type PREventResponse struct {
+	CreatedAt string `json:"created_at"`
+	Type      string `json:"type"`
+	Actor     string `json:"actor"`
+	UserID    string `json:"user_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Decision  string `json:"decision,omitempty"`
+}
@@ -6,6 +6,8 @@ type StatisticsResponse struct {
 
 type StatisticsData struct {
 	UserAssignments []UserAssignmentStat `json:"assignments"`
+	Series          []StatisticsPoint    `json:"series"`
+	Bucket          string               `json:"bucket"`
 	TotalPRs        int                  `json:"totalPRs"`
 	OpenPRs         int                  `json:"openPRs"`
 	MergedPRs       int                  `json:"mergedPRs"`
@@ -14,6 +16,13 @@ type StatisticsData struct {
 	ActiveUsers     int                  `json:"activeUsers"`
 }
 
+type StatisticsPoint struct {
+	BucketStart string `json:"bucketStart"`
+	CreatedPRs  int    `json:"createdPRs"`
+	MergedPRs   int    `json:"mergedPRs"`
+	Assignments int    `json:"assignments"`
+}
+
 type UserAssignmentStat struct {
 	UserID            string `json:"userId"`
 	Username          string `json:"username"`
@@ -22,3 +31,60 @@ type UserAssignmentStat struct {
 	OpenAssignments   int    `json:"openAssignments"`
 	MergedAssignments int    `json:"mergedAssignments"`
 }
+
+type LatencyStatisticsResponse struct {
+	From      string                    `json:"from"`
+	To        string                    `json:"to"`
+	Overall   LatencyMetricsResponse    `json:"overall"`
+	Teams     []TeamLatencyResponse     `json:"teams"`
+	Reviewers []ReviewerLatencyResponse `json:"reviewers"`
+}
+
+type LatencyResponse struct {
+	Count       int     `json:"count"`
+	MedianHours float64 `json:"medianHours"`
+	P90Hours    float64 `json:"p90Hours"`
+}
+
+type LatencyMetricsResponse struct {
+	TimeToMerge       LatencyResponse `json:"timeToMerge"`
+	TimeToFirstReview LatencyResponse `json:"timeToFirstReview"`
+	Assignments       int             `json:"assignments"`
+	Reassignments     int             `json:"reassignments"`
+	ReassignmentRate  float64         `json:"reassignmentRate"`
+}
+
+type TeamLatencyResponse struct {
+	LatencyMetricsResponse
+	TeamName string `json:"teamName"`
+}
+
+type ReviewerLatencyResponse struct {
+	TimeToFirstReview LatencyResponse `json:"timeToFirstReview"`
+	UserID            string          `json:"userId"`
+	Username          string          `json:"username"`
+	Assignments       int             `json:"assignments"`
+	Reassignments     int             `json:"reassignments"`
+	ReassignmentRate  float64         `json:"reassignmentRate"`
+}
+
+type FairnessReportResponse struct {
+	From  string                 `json:"from"`
+	To    string                 `json:"to"`
+	Teams []TeamFairnessResponse `json:"teams"`
+}
+
+type TeamFairnessResponse struct {
+	TeamName    string                     `json:"teamName"`
+	Reviewers   []ReviewerFairnessResponse `json:"reviewers"`
+	Mean        float64                    `json:"mean"`
+	Gini        float64                    `json:"gini"`
+	MaxMinRatio *float64                   `json:"maxMinRatio"`
+}
+
+type ReviewerFairnessResponse struct {
+	UserID      string  `json:"userId"`
+	Username    string  `json:"username"`
+	Assignments int     `json:"assignments"`
+	Deviation   float64 `json:"deviation"`
+}
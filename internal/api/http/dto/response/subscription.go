@@ -0,0 +1,32 @@
+package response
+
+// SubscriptionResponse never includes the secret.
+type SubscriptionResponse struct {
+	CreatedAt  *string  `json:"created_at,omitempty"`
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+type SubscriptionListResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
+type SubscriptionDeliveriesResponse struct {
+	SubscriptionID int64                  `json:"subscription_id"`
+	Deliveries     []SubscriptionDelivery `json:"deliveries"`
+}
+
+type SubscriptionDelivery struct {
+	CreatedAt      *string `json:"created_at,omitempty"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int    `json:"last_status_code,omitempty"`
+	LastError      string  `json:"last_error,omitempty"`
+	ID             int64   `json:"id"`
+	EventID        int64   `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	PullRequestID  string  `json:"pull_request_id"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+}
@@ -1,12 +1,50 @@
 package response
 
 type TeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName          string        `json:"team_name"`
+	ParentTeam        string        `json:"parent_team,omitempty"`
+	Settings          TeamSettings  `json:"settings"`
+	EffectiveSettings *TeamSettings `json:"effective_settings,omitempty"`
+	Members           []TeamMember  `json:"members"`
+	ExistingUsers     []string      `json:"existing_users,omitempty"`
+}
+
+type TeamListResponse struct {
+	Teams  []TeamSummary `json:"teams"`
+	Total  int           `json:"total"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+}
+
+type TeamSummary struct {
+	TeamName           string       `json:"team_name"`
+	ParentTeam         string       `json:"parent_team,omitempty"`
+	MembersCount       int          `json:"members_count"`
+	ActiveMembersCount int          `json:"active_members_count"`
+	Members            []TeamMember `json:"members,omitempty"`
+}
+
+type TeamSettings struct {
+	ReviewSLAMinutes *int    `json:"review_sla_minutes"`
+	ReviewerFallback *string `json:"reviewer_fallback"`
+}
+
+type TeamTreeResponse struct {
+	Teams []TeamNode `json:"teams"`
+}
+
+type TeamNode struct {
+	TeamName         string     `json:"team_name"`
+	Members          int        `json:"members"`
+	TotalMembers     int        `json:"total_members"`
+	OpenReviews      int        `json:"open_reviews"`
+	TotalOpenReviews int        `json:"total_open_reviews"`
+	Children         []TeamNode `json:"children"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Teams    []string `json:"teams"`
+	IsActive bool     `json:"is_active"`
 }
@@ -0,0 +1,17 @@
+package response
+
+// APITokenResponse only includes the token itself when it is created.
+type APITokenResponse struct {
+	CreatedAt  *string  `json:"created_at,omitempty"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	RevokedAt  *string  `json:"revoked_at,omitempty"`
+	Token      string   `json:"token,omitempty"`
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+}
+
+type APITokenListResponse struct {
+	Tokens []APITokenResponse `json:"tokens"`
+}
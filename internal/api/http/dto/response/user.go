@@ -1,13 +1,47 @@
 package response
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID        string   `json:"user_id"`
+	Username      string   `json:"username"`
+	Email         *string  `json:"email"`
+	TeamName      string   `json:"team_name"`
+	Teams         []string `json:"teams"`
+	Role          string   `json:"role"`
+	LeadOf        []string `json:"lead_of"`
+	Timezone      string   `json:"timezone"`
+	WorkStart     int      `json:"work_start"`
+	WorkEnd       int      `json:"work_end"`
+	OpenReviews   int      `json:"open_reviews"`
+	IsActive      bool     `json:"is_active"`
+	DigestEnabled bool     `json:"digest_enabled"`
+}
+
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
 }
 
 type UserReviewResponse struct {
 	UserID       string            `json:"user_id"`
 	PullRequests []PRShortResponse `json:"pull_requests"`
 }
+
+type UserDigestResponse struct {
+	UserID       string            `json:"user_id"`
+	Email        string            `json:"email"`
+	PullRequests []PRShortResponse `json:"pull_requests"`
+}
+
+type TeamMoveResponse struct {
+	UserID            string                 `json:"user_id"`
+	FromTeam          string                 `json:"from_team"`
+	ToTeam            string                 `json:"to_team"`
+	OpenReviews       string                 `json:"open_reviews"`
+	KeptReviews       []string               `json:"kept_reviews"`
+	UnassignedReviews []string               `json:"unassigned_reviews"`
+	ReassignedReviews []ReassignmentResponse `json:"reassigned_reviews"`
+}
+
+type ReassignmentResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReplacedBy    string `json:"replaced_by"`
+}
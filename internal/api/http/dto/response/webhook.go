@@ -0,0 +1,9 @@
+package response
+
+type WebhookResponse struct {
+	Event         string `json:"event"`
+	Action        string `json:"action,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Result        string `json:"result"`
+	Reason        string `json:"reason,omitempty"`
+}
@@ -0,0 +1,106 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type AuditLister interface {
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error)
+}
+
+func New(log *slog.Logger, auditLister AuditLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.audit.list.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		query := r.URL.Query()
+
+		filter := domain.AuditFilter{
+			EntityType: domain.AuditEntity(query.Get("entity_type")),
+			EntityID:   query.Get("entity_id"),
+			Actor:      query.Get("actor"),
+		}
+
+		var err error
+		if raw := query.Get("limit"); raw != "" {
+			filter.Limit, err = strconv.Atoi(raw)
+			if err != nil || filter.Limit < 1 {
+				log.Warn("invalid limit")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "limit must be a positive integer"))
+
+				return
+			}
+		}
+		if raw := query.Get("offset"); raw != "" {
+			filter.Offset, err = strconv.Atoi(raw)
+			if err != nil || filter.Offset < 0 {
+				log.Warn("invalid offset")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "offset must be a non-negative integer"))
+
+				return
+			}
+		}
+		if raw := query.Get("from"); raw != "" {
+			from, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid from")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "from must be an RFC 3339 time"))
+
+				return
+			}
+			filter.From = &from
+		}
+		if raw := query.Get("to"); raw != "" {
+			to, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid to")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "to must be an RFC 3339 time"))
+
+				return
+			}
+			filter.To = &to
+		}
+
+		events, err := auditLister.ListAuditEvents(r.Context(), filter)
+		if errors.Is(err, service.ErrInvalidAuditFilter) {
+			log.Warn("invalid audit filter")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest,
+				"entity_type must be pr, user, team, subscription or token, entity_id requires entity_type and from must be before to"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOAuditEventListFromDomain(events)
+
+		log.Info("successfully listed audit events")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
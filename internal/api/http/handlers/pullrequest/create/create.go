@@ -17,7 +17,7 @@ import (
 )
 
 type PRCreator interface {
-	CreatePR(ctx context.Context, prId string, prName string, authorId string) (*domain.PR, error)
+	CreatePR(ctx context.Context, prId string, prName string, authorId string, teamName string) (*domain.PR, error)
 }
 
 func New(log *slog.Logger, prCreator PRCreator) http.HandlerFunc {
@@ -39,7 +39,7 @@ func New(log *slog.Logger, prCreator PRCreator) http.HandlerFunc {
 		log = log.With(
 			slog.String("prId", req.PullRequestID))
 
-		createdPR, err := prCreator.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+		createdPR, err := prCreator.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.TeamName)
 		if errors.Is(err, service.ErrPRExists) {
 			log.Warn("PR already exists", sl.Err(err))
 			render.Status(r, http.StatusConflict)
@@ -61,6 +61,20 @@ func New(log *slog.Logger, prCreator PRCreator) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, service.ErrTeamRequired) {
+			log.Warn("team is required", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "author belongs to several teams, team_name is required"))
+
+			return
+		}
+		if errors.Is(err, service.ErrNotTeamMember) {
+			log.Warn("author is not a team member", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "author is not a member of the team"))
+
+			return
+		}
 		if err != nil {
 			log.Error("error creating PR", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
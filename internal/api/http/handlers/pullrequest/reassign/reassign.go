@@ -72,6 +72,13 @@ func New(log *slog.Logger, prAssigner PRAssigner) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, service.ErrPRClosed) {
+			log.Warn("PR closed")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodePRClosed, "PR closed"))
+
+			return
+		}
 		if errors.Is(err, service.ErrNoCandidates) {
 			log.Warn("no candidates")
 			render.Status(r, http.StatusConflict)
@@ -86,6 +93,27 @@ func New(log *slog.Logger, prAssigner PRAssigner) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, service.ErrAlreadyReviewer) {
+			log.Warn("new reviewer already assigned")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeAssigned, "new reviewer was assigned concurrently, retry"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "reviewers may only reassign themselves"))
+
+			return
+		}
 		if err != nil {
 			log.Error("error reassigning PR", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
@@ -0,0 +1,119 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type PRReviewer interface {
+	Review(ctx context.Context, prId string, reviewerId string, decision domain.ReviewDecision) ([]*domain.PREvent, error)
+}
+
+func New(log *slog.Logger, prReviewer PRReviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.pullrequest.review.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.PRReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		log = log.With(
+			slog.String("prId", req.PullRequestID))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		events, err := prReviewer.Review(r.Context(), req.PullRequestID, req.ReviewerID, domain.ReviewDecision(req.Decision))
+		if errors.Is(err, service.ErrPRNotFound) {
+			log.Warn("PR not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "PR Not Found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			log.Warn("PR merged")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodePRMerged, "PR merged"))
+
+			return
+		}
+		if errors.Is(err, service.ErrPRClosed) {
+			log.Warn("PR closed")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodePRClosed, "PR closed"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUserNotReviewer) {
+			log.Warn("user not reviewer")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotAssigned, "user not reviewer"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidDecision) {
+			log.Warn("invalid review decision")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid review decision"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "reviewers may only submit their own reviews"))
+
+			return
+		}
+		if err != nil {
+			log.Error("error reviewing PR", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "error reviewing PR"))
+
+			return
+		}
+
+		response := converter.ToDTOPRTimelineFromDomain(req.PullRequestID, events)
+
+		log.Info("pr reviewed successfully")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
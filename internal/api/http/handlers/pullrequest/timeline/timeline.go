@@ -0,0 +1,62 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TimelineProvider interface {
+	GetTimeline(ctx context.Context, prId string) ([]*domain.PREvent, error)
+}
+
+func New(log *slog.Logger, timelineProvider TimelineProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.pullrequest.timeline.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		prId := chi.URLParam(r, "id")
+		if prId == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "missing pull request id"))
+
+			return
+		}
+
+		log = log.With(slog.String("prId", prId))
+
+		events, err := timelineProvider.GetTimeline(r.Context(), prId)
+		if errors.Is(err, service.ErrPRNotFound) {
+			log.Warn("PR not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "PR not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get timeline", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "failed to get timeline"))
+
+			return
+		}
+
+		response := converter.ToDTOPRTimelineFromDomain(prId, events)
+
+		log.Info("successfully got timeline")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
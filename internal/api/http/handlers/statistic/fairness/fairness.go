@@ -0,0 +1,86 @@
+package fairness
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type FairnessProvider interface {
+	GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (*domain.FairnessReport, error)
+}
+
+func New(log *slog.Logger, fairnessProvider FairnessProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.statistic.fairness.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		query := r.URL.Query()
+
+		filter := domain.FairnessFilter{
+			TeamName: query.Get("team"),
+		}
+		var err error
+		if raw := query.Get("from"); raw != "" {
+			filter.From, err = time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid from")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "from must be an RFC 3339 time"))
+
+				return
+			}
+		}
+		if raw := query.Get("to"); raw != "" {
+			filter.To, err = time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid to")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "to must be an RFC 3339 time"))
+
+				return
+			}
+		}
+
+		report, err := fairnessProvider.GetFairnessReport(r.Context(), filter)
+		if errors.Is(err, service.ErrInvalidPeriod) {
+			log.Warn("invalid period")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "from must be before to"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			log.Warn("team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "team not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed getting fairness report", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "failed to get fairness report"))
+
+			return
+		}
+
+		response := converter.ToDTOFairnessReportFromDomain(report)
+
+		log.Info("success getting fairness report")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
@@ -2,18 +2,21 @@ package statistic
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/render"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
 	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
 	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
 type StatsProvider interface {
-	GetStatistics(ctx context.Context) (*domain.Statistics, error)
+	GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.Statistics, error)
 }
 
 func New(log *slog.Logger, statsProvider StatsProvider) http.HandlerFunc {
@@ -23,7 +26,58 @@ func New(log *slog.Logger, statsProvider StatsProvider) http.HandlerFunc {
 		log := log.With(
 			slog.String("op", op))
 
-		stats, err := statsProvider.GetStatistics(r.Context())
+		query := r.URL.Query()
+
+		filter := domain.StatisticsFilter{
+			TeamName: query.Get("team"),
+			Bucket:   domain.StatisticsBucket(query.Get("bucket")),
+		}
+		if raw := query.Get("from"); raw != "" {
+			from, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid from")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "from must be an RFC 3339 time"))
+
+				return
+			}
+			filter.From = &from
+		}
+		if raw := query.Get("to"); raw != "" {
+			to, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				log.Warn("invalid to")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "to must be an RFC 3339 time"))
+
+				return
+			}
+			filter.To = &to
+		}
+
+		stats, err := statsProvider.GetStatistics(r.Context(), filter)
+		if errors.Is(err, service.ErrInvalidBucket) {
+			log.Warn("invalid bucket")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "bucket must be day or week"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidPeriod) {
+			log.Warn("invalid period")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest,
+				"from must be before to and the period at most 366 buckets"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			log.Warn("team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "team not found"))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed getting stats", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
@@ -0,0 +1,73 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type SubscriptionCreator interface {
+	CreateSubscription(ctx context.Context, subscription domain.Subscription) (*domain.Subscription, error)
+}
+
+func New(log *slog.Logger, subscriptionCreator SubscriptionCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.subscriptions.create.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.SubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		subscription, err := subscriptionCreator.CreateSubscription(r.Context(), converter.ToDomainSubscriptionFromDTO(req))
+		if errors.Is(err, service.ErrInvalidSubscription) {
+			log.Warn("invalid subscription", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid subscription"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("subscription created", slog.Int64("subscriptionId", subscription.ID))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, converter.ToDTOSubscriptionFromDomain(subscription))
+	}
+}
@@ -0,0 +1,63 @@
+package deliveries
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type DeliveryGetter interface {
+	GetSubscriptionDeliveries(ctx context.Context, id int64) ([]*domain.SubscriptionDelivery, error)
+}
+
+// New returns the delivery log of a subscription, newest first.
+func New(log *slog.Logger, deliveryGetter DeliveryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.subscriptions.deliveries.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Warn("invalid subscription id")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid subscription id"))
+
+			return
+		}
+
+		log = log.With(slog.Int64("subscriptionId", id))
+
+		deliveries, err := deliveryGetter.GetSubscriptionDeliveries(r.Context(), id)
+		if errors.Is(err, service.ErrSubscriptionNotFound) {
+			log.Warn("subscription not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "subscription not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("deliveries found")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, converter.ToDTOSubscriptionDeliveriesFromDomain(id, deliveries))
+	}
+}
@@ -0,0 +1,60 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+)
+
+type SubscriptionRemover interface {
+	DeleteSubscription(ctx context.Context, id int64) error
+}
+
+func New(log *slog.Logger, subscriptionRemover SubscriptionRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.subscriptions.remove.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Warn("invalid subscription id")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid subscription id"))
+
+			return
+		}
+
+		log = log.With(slog.Int64("subscriptionId", id))
+
+		err = subscriptionRemover.DeleteSubscription(r.Context(), id)
+		if errors.Is(err, service.ErrSubscriptionNotFound) {
+			log.Warn("subscription not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "subscription not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("subscription deleted")
+
+		render.Status(r, http.StatusNoContent)
+		render.NoContent(w, r)
+	}
+}
@@ -62,6 +62,34 @@ func New(log *slog.Logger, teamAdder TeamAdder) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, service.ErrParentNotFound) {
+			log.Warn("parent team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "parent team not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidSettings) {
+			log.Warn("invalid team settings")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid team settings"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins may create teams"))
+
+			return
+		}
 		if err != nil {
 			log.Error("internal error", sl.Err(err))
 
@@ -0,0 +1,82 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TeamLister interface {
+	ListTeams(ctx context.Context, filter domain.TeamFilter) (*domain.TeamPage, error)
+}
+
+func New(log *slog.Logger, teamLister TeamLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.team.list.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		query := r.URL.Query()
+
+		filter := domain.TeamFilter{
+			Name:        query.Get("name"),
+			WithMembers: true,
+		}
+
+		var err error
+		if raw := query.Get("limit"); raw != "" {
+			filter.Limit, err = strconv.Atoi(raw)
+			if err != nil || filter.Limit < 1 {
+				log.Warn("invalid limit")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "limit must be a positive integer"))
+
+				return
+			}
+		}
+		if raw := query.Get("offset"); raw != "" {
+			filter.Offset, err = strconv.Atoi(raw)
+			if err != nil || filter.Offset < 0 {
+				log.Warn("invalid offset")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "offset must be a non-negative integer"))
+
+				return
+			}
+		}
+		if raw := query.Get("include_members"); raw != "" {
+			filter.WithMembers, err = strconv.ParseBool(raw)
+			if err != nil {
+				log.Warn("invalid include_members")
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "include_members must be a boolean"))
+
+				return
+			}
+		}
+
+		page, err := teamLister.ListTeams(r.Context(), filter)
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOTeamListFromDomain(page)
+
+		log.Info("successfully listed teams")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
@@ -0,0 +1,92 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TeamRemover interface {
+	Delete(ctx context.Context, teamName string, policy domain.OpenReviewsPolicy) (*domain.Team, error)
+}
+
+func New(log *slog.Logger, teamRemover TeamRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.team.remove.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		teamName := chi.URLParam(r, "name")
+		policy := domain.OpenReviewsPolicy(r.URL.Query().Get("open_reviews"))
+
+		log = log.With(slog.String("teamName", teamName))
+
+		deletedTeam, err := teamRemover.Delete(r.Context(), teamName, policy)
+		if errors.Is(err, service.ErrInvalidPolicy) {
+			log.Warn("invalid open reviews policy")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "open_reviews must be one of reject, unassign, keep"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			log.Warn("team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "team not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrOpenReviews) {
+			log.Warn("team members have open reviews")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeOpenReviews, "team members have open reviews"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamHasOpenPRs) {
+			log.Warn("team has open PRs")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeOpenPRs, "team has open PRs and no parent team to hand them to"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may remove the team"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOTeamFromDomain(deletedTeam)
+
+		log.Info("team deleted")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
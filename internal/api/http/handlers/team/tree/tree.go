@@ -0,0 +1,43 @@
+package tree
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TreeProvider interface {
+	GetTree(ctx context.Context) ([]*domain.TeamNode, error)
+}
+
+func New(log *slog.Logger, treeProvider TreeProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.team.tree.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		nodes, err := treeProvider.GetTree(r.Context())
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOTeamTreeFromDomain(nodes)
+
+		log.Info("successfully read team tree")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
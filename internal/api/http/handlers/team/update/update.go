@@ -0,0 +1,135 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TeamUpdater interface {
+	Update(ctx context.Context, teamName string, update domain.TeamUpdate) (*domain.Team, error)
+}
+
+func New(log *slog.Logger, teamUpdater TeamUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.team.update.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		teamName := chi.URLParam(r, "name")
+		log = log.With(slog.String("teamName", teamName))
+
+		var req request.TeamUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		updatedTeam, err := teamUpdater.Update(r.Context(), teamName, converter.ToDomainTeamUpdateFromDTO(req))
+		if errors.Is(err, service.ErrTeamNotFound) {
+			log.Warn("team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "team not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamExists) {
+			log.Warn("team already exists")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeTeamExists, req.TeamName+" already exists"))
+
+			return
+		}
+		if errors.Is(err, service.ErrOpenReviews) {
+			log.Warn("removed members have open reviews")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeOpenReviews, "removed members have open reviews"))
+
+			return
+		}
+		if errors.Is(err, service.ErrNotTeamMember) {
+			log.Warn("removed user is not a team member", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "remove_members must be team members"))
+
+			return
+		}
+		if errors.Is(err, service.ErrParentNotFound) {
+			log.Warn("parent team not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "parent team not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamCycle) {
+			log.Warn("parent team is a descendant of the team")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeTeamCycle, "team cannot be its own ancestor"))
+
+			return
+		}
+		if errors.Is(err, service.ErrInvalidSettings) {
+			log.Warn("invalid team settings")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid team settings"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may update the team"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOTeamFromDomain(updatedTeam)
+
+		log.Info("team updated")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
@@ -0,0 +1,75 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TokenCreator interface {
+	CreateAPIToken(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIToken, error)
+}
+
+// New issues an API token. The response is the only place the token is ever
+// shown.
+func New(log *slog.Logger, tokenCreator TokenCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.tokens.create.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.APITokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		token, err := tokenCreator.CreateAPIToken(r.Context(), req.Name, converter.ToDomainScopesFromDTO(req.Scopes))
+		if errors.Is(err, service.ErrInvalidToken) {
+			log.Warn("invalid token", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid token"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("token created", slog.Int64("tokenId", token.ID))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, converter.ToDTOAPITokenFromDomain(token))
+	}
+}
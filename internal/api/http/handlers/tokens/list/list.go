@@ -0,0 +1,40 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TokenLister interface {
+	ListAPITokens(ctx context.Context) ([]*domain.APIToken, error)
+}
+
+func New(log *slog.Logger, tokenLister TokenLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.tokens.list.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		tokens, err := tokenLister.ListAPITokens(r.Context())
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("tokens listed")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, converter.ToDTOAPITokenListFromDomain(tokens))
+	}
+}
@@ -0,0 +1,62 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TokenRevoker interface {
+	RevokeAPIToken(ctx context.Context, id int64) (*domain.APIToken, error)
+}
+
+func New(log *slog.Logger, tokenRevoker TokenRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.tokens.revoke.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Warn("invalid token id")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid token id"))
+
+			return
+		}
+
+		log = log.With(slog.Int64("tokenId", id))
+
+		token, err := tokenRevoker.RevokeAPIToken(r.Context(), id)
+		if errors.Is(err, service.ErrTokenNotFound) {
+			log.Warn("token not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "token not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		log.Info("token revoked")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, converter.ToDTOAPITokenFromDomain(token))
+	}
+}
@@ -2,6 +2,7 @@ package get_review
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
 	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
 	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
@@ -33,6 +35,13 @@ func New(log *slog.Logger, userReviewProvider UserReviewProvider) http.HandlerFu
 		log.With(slog.String("userId", userId))
 
 		reviews, err := userReviewProvider.GetReview(r.Context(), userId)
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "user not found"))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to get reviews", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
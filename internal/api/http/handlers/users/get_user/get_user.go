@@ -0,0 +1,55 @@
+package get_user
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type UserProvider interface {
+	GetUser(ctx context.Context, userId string) (*domain.User, error)
+}
+
+func New(log *slog.Logger, userProvider UserProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.users.get_user.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		userId := chi.URLParam(r, "id")
+		log = log.With(slog.String("userId", userId))
+
+		user, err := userProvider.GetUser(r.Context(), userId)
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn("user not found")
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "user not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOUserFromDomain(user)
+
+		log.Info("successfully got user")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
@@ -0,0 +1,62 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type UserLister interface {
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error)
+}
+
+func New(log *slog.Logger, userLister UserLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.users.list.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		query := r.URL.Query()
+
+		filter := domain.UserFilter{
+			TeamName: query.Get("team_name"),
+			Search:   query.Get("search"),
+		}
+
+		if rawIsActive := query.Get("is_active"); rawIsActive != "" {
+			isActive, err := strconv.ParseBool(rawIsActive)
+			if err != nil {
+				log.Warn("invalid is_active", sl.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "is_active must be a boolean"))
+
+				return
+			}
+			filter.IsActive = &isActive
+		}
+
+		users, err := userLister.ListUsers(r.Context(), filter)
+		if err != nil {
+			log.Error("internal error", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+			return
+		}
+
+		response := converter.ToDTOUserListFromDomain(users)
+
+		log.Info("successfully listed users")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
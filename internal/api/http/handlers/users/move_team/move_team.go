@@ -0,0 +1,154 @@
+package move_team
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type TeamMover interface {
+	MoveTeam(
+		ctx context.Context,
+		userId string,
+		fromTeamName string,
+		teamName string,
+		policy domain.OpenReviewsPolicy,
+	) (*domain.TeamMove, error)
+}
+
+func New(log *slog.Logger, teamMover TeamMover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.users.move_team.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.UserMoveTeamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		log = log.With(
+			slog.String("userId", req.UserID),
+			slog.String("teamName", req.TeamName))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		move, err := teamMover.MoveTeam(
+			r.Context(),
+			req.UserID,
+			req.FromTeamName,
+			req.TeamName,
+			domain.OpenReviewsPolicy(req.OpenReviews),
+		)
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "user not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamNotFound) {
+			log.Warn("team not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "team not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrAlreadyInTeam) {
+			log.Warn("user already in team", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "user already in team"))
+
+			return
+		}
+		if errors.Is(err, service.ErrTeamRequired) {
+			log.Warn("source team is required", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "user belongs to several teams, from_team_name is required"))
+
+			return
+		}
+		if errors.Is(err, service.ErrNotTeamMember) {
+			log.Warn("user is not a member of the source team", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "user is not a member of from_team_name"))
+
+			return
+		}
+		if errors.Is(err, service.ErrOpenReviews) {
+			log.Warn("user has open reviews", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeOpenReviews, "user has open reviews"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUserNotReviewer) {
+			log.Warn("user is no longer a reviewer of a reassigned PR", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotAssigned, "reviews changed concurrently, retry"))
+
+			return
+		}
+		if errors.Is(err, service.ErrAlreadyReviewer) {
+			log.Warn("new reviewer already assigned", sl.Err(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeAssigned, "new reviewer was assigned concurrently, retry"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may move users"))
+
+			return
+		}
+		if err != nil {
+			log.Error("error moving user", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "error moving user"))
+
+			return
+		}
+
+		response := converter.ToDTOTeamMoveFromDomain(move)
+
+		log.Info("user moved successfully")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
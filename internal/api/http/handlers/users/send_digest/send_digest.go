@@ -0,0 +1,105 @@
+package send_digest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type DigestSender interface {
+	SendDigest(ctx context.Context, userId string) (*domain.Digest, error)
+}
+
+// New emails the user their review digest right away, e.g. to preview it
+// against a local SMTP sink.
+func New(log *slog.Logger, digestSender DigestSender) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.users.send_digest.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.UserDigestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		log = log.With(slog.String("userId", req.UserID))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		digest, err := digestSender.SendDigest(r.Context(), req.UserID)
+		if errors.Is(err, service.ErrDigestDisabled) {
+			log.Warn("email digest is not configured", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "email digest is not configured"))
+
+			return
+		}
+		if errors.Is(err, service.ErrNoEmail) {
+			log.Warn("user has no email", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "user has no email"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "user not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may send a teammate's digest"))
+
+			return
+		}
+		if err != nil {
+			log.Error("error sending digest", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "error sending digest"))
+
+			return
+		}
+
+		log.Info("digest sent successfully")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, converter.ToDTOUserDigestFromDomain(digest))
+	}
+}
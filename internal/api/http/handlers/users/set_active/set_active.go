@@ -59,6 +59,20 @@ func New(log *slog.Logger, userActivitySetter UserActivityChanger) http.HandlerF
 
 			return
 		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may change teammates"))
+
+			return
+		}
 		if err != nil {
 			log.Error("error setting user activity", sl.Err(err))
 			render.Status(r, http.StatusInternalServerError)
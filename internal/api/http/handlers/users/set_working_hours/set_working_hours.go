@@ -0,0 +1,102 @@
+package set_working_hours
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type WorkingHoursSetter interface {
+	SetWorkingHours(ctx context.Context, userId string, workingHours domain.WorkingHours) (*domain.User, error)
+}
+
+func New(log *slog.Logger, workingHoursSetter WorkingHoursSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.users.set_working_hours.New"
+
+		log := log.With(
+			slog.String("op", op))
+
+		var req request.UserWorkingHoursRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		log = log.With(slog.String("userId", req.UserID))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.ValidationError(validateErr))
+
+			return
+		}
+
+		updatedUser, err := workingHoursSetter.SetWorkingHours(
+			r.Context(),
+			req.UserID,
+			converter.ToDomainWorkingHoursFromDTO(req),
+		)
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidHours) {
+			log.Warn("invalid working hours", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "invalid timezone or working hours"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn("user not found", sl.Err(err))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeNotFound, "user not found"))
+
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			log.Warn("unknown caller", sl.Err(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "unknown caller"))
+
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			log.Warn("forbidden", sl.Err(err))
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden, "only admins and team leads may change teammates"))
+
+			return
+		}
+		if err != nil {
+			log.Error("error setting working hours", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "error setting working hours"))
+
+			return
+		}
+
+		response := converter.ToDTOUserFromDomain(updatedUser)
+
+		log.Info("user working hours set successfully")
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, response)
+	}
+}
@@ -0,0 +1,165 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const provider = "gitea"
+
+// workInProgressPrefixes are Gitea's default title prefixes for pull
+// requests that are not ready for review yet.
+var workInProgressPrefixes = []string{"wip:", "[wip]"}
+
+// New handles Gitea and Forgejo webhook deliveries. Only pull_request events
+// change state. Payloads are signed with a hex HMAC-SHA256 in
+// X-Gitea-Signature, and redeliveries are recognized by X-Gitea-Delivery.
+// Forgejo sends the same headers with an X-Forgejo- prefix as well.
+func New(log *slog.Logger, cfg config.GiteaConfig, handler webhooks.VCSEventHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.webhooks.gitea.New"
+
+		event := header(r, "Event")
+		deliveryId := header(r, "Delivery")
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("event", event),
+			slog.String("delivery", deliveryId))
+
+		if cfg.WebhookSecret() == "" {
+			log.Warn("gitea webhook secret is not configured")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "gitea webhook is not configured"))
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhooks.MaxPayloadSize))
+		if err != nil {
+			log.Error("error reading body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error reading body"))
+
+			return
+		}
+
+		if !signature.VerifySHA256(cfg.WebhookSecret(), body, header(r, "Signature")) {
+			log.Warn("invalid signature")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "invalid signature"))
+
+			return
+		}
+
+		if event != "pull_request" {
+			webhooks.Ignore(w, r, log, response.WebhookResponse{Event: event}, "unsupported event")
+
+			return
+		}
+
+		var payload request.GiteaPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		resp := response.WebhookResponse{
+			Event:         event,
+			Action:        payload.Action,
+			PullRequestID: PullRequestID(payload.PullRequest),
+		}
+		log = log.With(
+			slog.String("action", payload.Action),
+			slog.String("prId", resp.PullRequestID))
+
+		vcsEvent, reason := normalize(cfg, deliveryId, payload)
+		if reason != "" {
+			webhooks.Ignore(w, r, log, resp, reason)
+
+			return
+		}
+
+		webhooks.Apply(w, r, log, handler, vcsEvent, resp)
+	}
+}
+
+// PullRequestID derives the PR ID from Gitea's instance-wide pull request
+// ID, which keeps IDs unique across repositories and free of slashes.
+func PullRequestID(pr request.GiteaPullRequest) string {
+	return fmt.Sprintf("gitea-%d", pr.ID)
+}
+
+// header reads a Gitea webhook header, falling back to its Forgejo name.
+func header(r *http.Request, name string) string {
+	if value := r.Header.Get("X-Gitea-" + name); value != "" {
+		return value
+	}
+
+	return r.Header.Get("X-Forgejo-" + name)
+}
+
+// normalize maps a pull_request event, or returns why it is ignored. Gitea
+// marks drafts with a WIP title prefix, so an edit of an open pull request
+// counts as opened: the edit that drops the prefix registers it.
+func normalize(cfg config.GiteaConfig, deliveryId string, payload request.GiteaPullRequestEvent) (domain.VCSEvent, string) {
+	pr := payload.PullRequest
+	event := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  deliveryId,
+		PRID:        PullRequestID(pr),
+		Repository:  payload.Repository.FullName,
+		Number:      pr.Number,
+		Title:       pr.Title,
+		AuthorLogin: pr.User.Login,
+		Draft:       pr.Draft || isWorkInProgress(pr.Title),
+	}
+	event.AuthorID, _ = cfg.UserID(pr.User.Login)
+
+	switch payload.Action {
+	case "opened", "edited":
+		if pr.State != "" && pr.State != "open" {
+			return domain.VCSEvent{}, "pull request is " + pr.State
+		}
+		event.Action = domain.VCSActionOpened
+	case "reopened":
+		event.Action = domain.VCSActionReopened
+	case "closed":
+		event.Action = domain.VCSActionClosed
+		if pr.Merged {
+			event.Action = domain.VCSActionMerged
+		}
+	default:
+		return domain.VCSEvent{}, "unsupported action"
+	}
+
+	return event, ""
+}
+
+func isWorkInProgress(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, prefix := range workInProgressPrefixes {
+		if strings.HasPrefix(title, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,242 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	testSecret   = "gitea-test-secret"
+	testDelivery = "5c1a1c0e-8f4b-4b52-a8a4-3d0c7e2f9b61"
+)
+
+type recordingHandler struct {
+	events []domain.VCSEvent
+}
+
+func (h *recordingHandler) HandleVCSEvent(_ context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	h.events = append(h.events, event)
+	return &domain.VCSEventResult{Outcome: domain.VCSOutcomeCreated}, nil
+}
+
+func newConfig(t *testing.T, secret string) config.GiteaConfig {
+	t.Helper()
+
+	t.Setenv("GITEA_WEBHOOK_SECRET", secret)
+	t.Setenv("GITEA_USER_MAPPING", "Alice=u1")
+
+	cfg, err := config.NewGiteaConfig()
+	if err != nil {
+		t.Fatalf("NewGiteaConfig: %v", err)
+	}
+
+	return cfg
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	return body
+}
+
+// deliver posts the body with headers under the prefix, X-Gitea- or
+// X-Forgejo-.
+func deliver(
+	t *testing.T,
+	cfg config.GiteaConfig,
+	handler *recordingHandler,
+	prefix string,
+	event string,
+	body []byte,
+	sig string,
+) (*httptest.ResponseRecorder, response.WebhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitea", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(prefix+"Event", event)
+	req.Header.Set(prefix+"Delivery", testDelivery)
+	if sig != "" {
+		req.Header.Set(prefix+"Signature", sig)
+	}
+
+	rec := httptest.NewRecorder()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	New(log, cfg, handler).ServeHTTP(rec, req)
+
+	var resp response.WebhookResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+
+	return rec, resp
+}
+
+func TestNewNormalizesFixtures(t *testing.T) {
+	opened := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  testDelivery,
+		Action:      domain.VCSActionOpened,
+		PRID:        "gitea-3821",
+		Repository:  "platform/billing",
+		Title:       "Add invoice export",
+		AuthorID:    "u1",
+		AuthorLogin: "alice",
+		Number:      17,
+	}
+	with := func(action domain.VCSAction) domain.VCSEvent {
+		event := opened
+		event.Action = action
+		return event
+	}
+	wip := opened
+	wip.Title = "WIP: Add invoice export"
+	wip.Draft = true
+
+	tests := []struct {
+		fixture string
+		prefix  string
+		want    domain.VCSEvent
+	}{
+		{fixture: "pull_request_opened", prefix: "X-Gitea-", want: opened},
+		{fixture: "pull_request_opened", prefix: "X-Forgejo-", want: opened},
+		{fixture: "pull_request_opened_wip", prefix: "X-Gitea-", want: wip},
+		{fixture: "pull_request_edited", prefix: "X-Gitea-", want: opened},
+		{fixture: "pull_request_reopened", prefix: "X-Gitea-", want: with(domain.VCSActionReopened)},
+		{fixture: "pull_request_closed", prefix: "X-Gitea-", want: with(domain.VCSActionClosed)},
+		{fixture: "pull_request_merged", prefix: "X-Gitea-", want: with(domain.VCSActionMerged)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix+tt.fixture, func(t *testing.T) {
+			cfg := newConfig(t, testSecret)
+			handler := &recordingHandler{}
+			body := readFixture(t, tt.fixture)
+
+			rec, resp := deliver(t, cfg, handler, tt.prefix, "pull_request", body, signature.SignSHA256(testSecret, body))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 1 {
+				t.Fatalf("handled %d events, want 1", len(handler.events))
+			}
+			if got := handler.events[0]; got != tt.want {
+				t.Errorf("event = %+v, want %+v", got, tt.want)
+			}
+			if resp.PullRequestID != tt.want.PRID || resp.Result != string(domain.VCSOutcomeCreated) {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestNewIgnoresUnsupportedDeliveries(t *testing.T) {
+	closedEdit := bytes.Replace(readFixture(t, "pull_request_closed"),
+		[]byte(`"action": "closed"`), []byte(`"action": "edited"`), 1)
+
+	tests := []struct {
+		name   string
+		event  string
+		body   []byte
+		reason string
+	}{
+		{
+			name:   "unsupported action",
+			event:  "pull_request",
+			body:   readFixture(t, "pull_request_synchronized"),
+			reason: "unsupported action",
+		},
+		{
+			name:   "edit of a closed pull request",
+			event:  "pull_request",
+			body:   closedEdit,
+			reason: "pull request is closed",
+		},
+		{
+			name:   "unsupported event",
+			event:  "push",
+			body:   readFixture(t, "pull_request_opened"),
+			reason: "unsupported event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, testSecret)
+			handler := &recordingHandler{}
+
+			rec, resp := deliver(t, cfg, handler, "X-Gitea-", tt.event, tt.body, signature.SignSHA256(testSecret, tt.body))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+			if resp.Result != "ignored" || resp.Reason != tt.reason {
+				t.Errorf("response = %+v, want reason %q", resp, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNewVerifiesSignature(t *testing.T) {
+	body := readFixture(t, "pull_request_opened")
+	tampered := bytes.Replace(body, []byte(`"Add invoice export"`), []byte(`"Drop all tables"`), 1)
+	valid := signature.SignSHA256(testSecret, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		sig    string
+		want   int
+	}{
+		{name: "valid", secret: testSecret, body: body, sig: valid, want: http.StatusOK},
+		{name: "missing", secret: testSecret, body: body, want: http.StatusUnauthorized},
+		{
+			name:   "other secret",
+			secret: testSecret,
+			body:   body,
+			sig:    signature.SignSHA256("other-secret", body),
+			want:   http.StatusUnauthorized,
+		},
+		{name: "tampered payload", secret: testSecret, body: tampered, sig: valid, want: http.StatusUnauthorized},
+		{name: "github prefix", secret: testSecret, body: body, sig: "sha256=" + valid, want: http.StatusUnauthorized},
+		{name: "not configured", secret: "", body: body, sig: valid, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, tt.secret)
+			handler := &recordingHandler{}
+
+			rec, _ := deliver(t, cfg, handler, "X-Gitea-", "pull_request", tt.body, tt.sig)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK && len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const provider = "github"
+
+// New handles GitHub webhook deliveries. Only pull_request events change
+// state; other events are acknowledged and ignored. Redeliveries are
+// recognized by X-GitHub-Delivery.
+func New(log *slog.Logger, cfg config.GitHubConfig, handler webhooks.VCSEventHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.webhooks.github.New"
+
+		event := r.Header.Get("X-GitHub-Event")
+		deliveryId := r.Header.Get("X-GitHub-Delivery")
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("event", event),
+			slog.String("delivery", deliveryId))
+
+		if cfg.WebhookSecret() == "" {
+			log.Warn("github webhook secret is not configured")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "github webhook is not configured"))
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhooks.MaxPayloadSize))
+		if err != nil {
+			log.Error("error reading body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error reading body"))
+
+			return
+		}
+
+		sig, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+		if !ok || !signature.VerifySHA256(cfg.WebhookSecret(), body, sig) {
+			log.Warn("invalid signature")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "invalid signature"))
+
+			return
+		}
+
+		switch event {
+		case "ping":
+			render.Status(r, http.StatusOK)
+			render.JSON(w, r, response.WebhookResponse{Event: event, Result: webhooks.ResultPong})
+
+			return
+		case "pull_request":
+		default:
+			webhooks.Ignore(w, r, log, response.WebhookResponse{Event: event}, "unsupported event")
+
+			return
+		}
+
+		var payload request.GitHubPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		resp := response.WebhookResponse{
+			Event:         event,
+			Action:        payload.Action,
+			PullRequestID: PullRequestID(payload.PullRequest),
+		}
+		log = log.With(
+			slog.String("action", payload.Action),
+			slog.String("prId", resp.PullRequestID))
+
+		vcsEvent, reason := normalize(cfg, deliveryId, payload)
+		if reason != "" {
+			webhooks.Ignore(w, r, log, resp, reason)
+
+			return
+		}
+
+		webhooks.Apply(w, r, log, handler, vcsEvent, resp)
+	}
+}
+
+// PullRequestID derives the PR ID from GitHub's numeric pull request ID,
+// which is unique across repositories and keeps IDs free of slashes.
+func PullRequestID(pr request.GitHubPullRequest) string {
+	return fmt.Sprintf("github-%d", pr.ID)
+}
+
+// normalize maps a pull_request event, or returns why it is ignored. GitHub
+// reports merges as closed events with the merged flag set.
+func normalize(cfg config.GitHubConfig, deliveryId string, payload request.GitHubPullRequestEvent) (domain.VCSEvent, string) {
+	pr := payload.PullRequest
+	event := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  deliveryId,
+		PRID:        PullRequestID(pr),
+		Repository:  payload.Repository.FullName,
+		Number:      pr.Number,
+		Title:       pr.Title,
+		AuthorLogin: pr.User.Login,
+		Draft:       pr.Draft,
+	}
+	event.AuthorID, _ = cfg.UserID(pr.User.Login)
+
+	switch payload.Action {
+	case "opened", "ready_for_review":
+		event.Action = domain.VCSActionOpened
+	case "reopened":
+		event.Action = domain.VCSActionReopened
+	case "closed":
+		event.Action = domain.VCSActionClosed
+		if pr.Merged {
+			event.Action = domain.VCSActionMerged
+		}
+	default:
+		return domain.VCSEvent{}, "unsupported action"
+	}
+
+	return event, ""
+}
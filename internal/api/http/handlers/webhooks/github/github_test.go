@@ -0,0 +1,246 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	testSecret   = "github-test-secret"
+	testDelivery = "72d3162e-cc78-11e3-81ab-4c9367dc0958"
+)
+
+type recordingHandler struct {
+	events []domain.VCSEvent
+}
+
+func (h *recordingHandler) HandleVCSEvent(_ context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	h.events = append(h.events, event)
+	return &domain.VCSEventResult{Outcome: domain.VCSOutcomeCreated}, nil
+}
+
+func newConfig(t *testing.T, secret string) config.GitHubConfig {
+	t.Helper()
+
+	t.Setenv("GITHUB_WEBHOOK_SECRET", secret)
+	t.Setenv("GITHUB_USER_MAPPING", "OctoCat=u1")
+
+	cfg, err := config.NewGitHubConfig()
+	if err != nil {
+		t.Fatalf("NewGitHubConfig: %v", err)
+	}
+
+	return cfg
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	return body
+}
+
+func deliver(
+	t *testing.T,
+	cfg config.GitHubConfig,
+	handler *recordingHandler,
+	event string,
+	body []byte,
+	sig string,
+) (*httptest.ResponseRecorder, response.WebhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", testDelivery)
+	if sig != "" {
+		req.Header.Set("X-Hub-Signature-256", sig)
+	}
+
+	rec := httptest.NewRecorder()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	New(log, cfg, handler).ServeHTTP(rec, req)
+
+	var resp response.WebhookResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+
+	return rec, resp
+}
+
+func signed(body []byte) string {
+	return "sha256=" + signature.SignSHA256(testSecret, body)
+}
+
+func TestNewNormalizesFixtures(t *testing.T) {
+	opened := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  testDelivery,
+		Action:      domain.VCSActionOpened,
+		PRID:        "github-2023456789",
+		Repository:  "acme/pr-reviewer",
+		Title:       "Add team hierarchy",
+		AuthorID:    "u1",
+		AuthorLogin: "octocat",
+		Number:      42,
+	}
+	with := func(action domain.VCSAction, draft bool) domain.VCSEvent {
+		event := opened
+		event.Action = action
+		event.Draft = draft
+		return event
+	}
+
+	tests := []struct {
+		fixture string
+		want    domain.VCSEvent
+	}{
+		{fixture: "pull_request_opened", want: opened},
+		{fixture: "pull_request_opened_draft", want: with(domain.VCSActionOpened, true)},
+		{fixture: "pull_request_ready_for_review", want: opened},
+		{fixture: "pull_request_reopened", want: with(domain.VCSActionReopened, false)},
+		{fixture: "pull_request_closed", want: with(domain.VCSActionClosed, false)},
+		{fixture: "pull_request_merged", want: with(domain.VCSActionMerged, false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			cfg := newConfig(t, testSecret)
+			handler := &recordingHandler{}
+			body := readFixture(t, tt.fixture)
+
+			rec, resp := deliver(t, cfg, handler, "pull_request", body, signed(body))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 1 {
+				t.Fatalf("handled %d events, want 1", len(handler.events))
+			}
+			if got := handler.events[0]; got != tt.want {
+				t.Errorf("event = %+v, want %+v", got, tt.want)
+			}
+			if resp.PullRequestID != tt.want.PRID || resp.Result != string(domain.VCSOutcomeCreated) {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestNewIgnoresUnsupportedDeliveries(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		result  string
+		reason  string
+	}{
+		{
+			name:    "ping",
+			event:   "ping",
+			fixture: "ping",
+			result:  "pong",
+		},
+		{
+			name:    "unsupported action",
+			event:   "pull_request",
+			fixture: "pull_request_synchronize",
+			result:  "ignored",
+			reason:  "unsupported action",
+		},
+		{
+			name:    "unsupported event",
+			event:   "issues",
+			fixture: "pull_request_opened",
+			result:  "ignored",
+			reason:  "unsupported event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, testSecret)
+			handler := &recordingHandler{}
+			body := readFixture(t, tt.fixture)
+
+			rec, resp := deliver(t, cfg, handler, tt.event, body, signed(body))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+			if resp.Result != tt.result || resp.Reason != tt.reason {
+				t.Errorf("response = %+v, want result %q reason %q", resp, tt.result, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNewVerifiesSignature(t *testing.T) {
+	body := readFixture(t, "pull_request_opened")
+	tampered := bytes.Replace(body, []byte(`"Add team hierarchy"`), []byte(`"Drop all tables"`), 1)
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		sig    string
+		want   int
+	}{
+		{name: "valid", secret: testSecret, body: body, sig: signed(body), want: http.StatusOK},
+		{name: "missing", secret: testSecret, body: body, want: http.StatusUnauthorized},
+		{
+			name:   "without prefix",
+			secret: testSecret,
+			body:   body,
+			sig:    signature.SignSHA256(testSecret, body),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "other secret",
+			secret: testSecret,
+			body:   body,
+			sig:    "sha256=" + signature.SignSHA256("other-secret", body),
+			want:   http.StatusUnauthorized,
+		},
+		{name: "tampered payload", secret: testSecret, body: tampered, sig: signed(body), want: http.StatusUnauthorized},
+		{name: "not hex", secret: testSecret, body: body, sig: "sha256=not-hex", want: http.StatusUnauthorized},
+		{name: "not configured", secret: "", body: body, sig: signed(body), want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, tt.secret)
+			handler := &recordingHandler{}
+
+			rec, _ := deliver(t, cfg, handler, "pull_request", tt.body, tt.sig)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK && len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/request"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	provider     = "gitlab"
+	mergeRequest = "Merge Request Hook"
+)
+
+// New handles GitLab webhook deliveries. Only Merge Request Hook events
+// change state. Redeliveries are recognized by X-Gitlab-Event-UUID.
+func New(log *slog.Logger, cfg config.GitLabConfig, handler webhooks.VCSEventHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "internal.api.http.handlers.webhooks.gitlab.New"
+
+		event := r.Header.Get("X-Gitlab-Event")
+		deliveryId := r.Header.Get("X-Gitlab-Event-UUID")
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("event", event),
+			slog.String("delivery", deliveryId))
+
+		if cfg.WebhookToken() == "" {
+			log.Warn("gitlab webhook token is not configured")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "gitlab webhook is not configured"))
+
+			return
+		}
+
+		if !signature.VerifyToken(cfg.WebhookToken(), r.Header.Get("X-Gitlab-Token")) {
+			log.Warn("invalid token")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, "invalid token"))
+
+			return
+		}
+
+		if event != mergeRequest {
+			webhooks.Ignore(w, r, log, response.WebhookResponse{Event: event}, "unsupported event")
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhooks.MaxPayloadSize))
+		if err != nil {
+			log.Error("error reading body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error reading body"))
+
+			return
+		}
+
+		var payload request.GitLabMergeRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Error("error decoding body", sl.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, "error decoding body"))
+
+			return
+		}
+
+		resp := response.WebhookResponse{
+			Event:         event,
+			Action:        payload.ObjectAttributes.Action,
+			PullRequestID: PullRequestID(payload.ObjectAttributes),
+		}
+		log = log.With(
+			slog.String("action", resp.Action),
+			slog.String("prId", resp.PullRequestID))
+
+		vcsEvent, reason := normalize(cfg, deliveryId, payload)
+		if reason != "" {
+			webhooks.Ignore(w, r, log, resp, reason)
+
+			return
+		}
+
+		webhooks.Apply(w, r, log, handler, vcsEvent, resp)
+	}
+}
+
+// PullRequestID derives the PR ID from GitLab's instance-wide merge request
+// ID, which keeps IDs unique across projects and free of slashes.
+func PullRequestID(mr request.GitLabMergeRequest) string {
+	return fmt.Sprintf("gitlab-%d", mr.ID)
+}
+
+// normalize maps a merge request event, or returns why it is ignored. Every
+// update of an open merge request counts as opened so that the update which
+// marks a draft ready registers it; updates of merge requests that are no
+// longer open change nothing.
+func normalize(cfg config.GitLabConfig, deliveryId string, payload request.GitLabMergeRequestEvent) (domain.VCSEvent, string) {
+	mr := payload.ObjectAttributes
+	event := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  deliveryId,
+		PRID:        PullRequestID(mr),
+		Repository:  payload.Project.PathWithNamespace,
+		Number:      mr.IID,
+		Title:       mr.Title,
+		AuthorLogin: strconv.FormatInt(mr.AuthorID, 10),
+		Draft:       mr.Draft || mr.WorkInProgress,
+	}
+	if payload.User.ID == mr.AuthorID && payload.User.Username != "" {
+		event.AuthorLogin = payload.User.Username
+	}
+	event.AuthorID, _ = authorUserID(cfg, payload)
+
+	switch mr.Action {
+	case "open", "update":
+		if mr.State != "" && mr.State != "opened" {
+			return domain.VCSEvent{}, "merge request is " + mr.State
+		}
+		event.Action = domain.VCSActionOpened
+	case "reopen":
+		event.Action = domain.VCSActionReopened
+	case "merge":
+		event.Action = domain.VCSActionMerged
+	case "close":
+		event.Action = domain.VCSActionClosed
+	default:
+		return domain.VCSEvent{}, "unsupported action"
+	}
+
+	return event, ""
+}
+
+// authorUserID maps the merge request author. The payload only carries the
+// author's numeric ID, so the username is only known when the author
+// triggered the event.
+func authorUserID(cfg config.GitLabConfig, payload request.GitLabMergeRequestEvent) (string, bool) {
+	authorId := payload.ObjectAttributes.AuthorID
+	if userId, ok := cfg.UserID(strconv.FormatInt(authorId, 10)); ok {
+		return userId, true
+	}
+	if payload.User.ID == authorId && payload.User.Username != "" {
+		return cfg.UserID(payload.User.Username)
+	}
+
+	return "", false
+}
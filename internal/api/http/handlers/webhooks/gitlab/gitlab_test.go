@@ -0,0 +1,243 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	testToken    = "gitlab-test-token"
+	testDelivery = "b5a8ba93-b0fb-4cae-9dfe-3ef4d4bc0d4f"
+)
+
+type recordingHandler struct {
+	events []domain.VCSEvent
+}
+
+func (h *recordingHandler) HandleVCSEvent(_ context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	h.events = append(h.events, event)
+	return &domain.VCSEventResult{Outcome: domain.VCSOutcomeCreated}, nil
+}
+
+func newConfig(t *testing.T, token string, mapping string) config.GitLabConfig {
+	t.Helper()
+
+	t.Setenv("GITLAB_WEBHOOK_TOKEN", token)
+	t.Setenv("GITLAB_USER_MAPPING", mapping)
+
+	cfg, err := config.NewGitLabConfig()
+	if err != nil {
+		t.Fatalf("NewGitLabConfig: %v", err)
+	}
+
+	return cfg
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	return body
+}
+
+func deliver(
+	t *testing.T,
+	cfg config.GitLabConfig,
+	handler *recordingHandler,
+	event string,
+	body []byte,
+	token string,
+) (*httptest.ResponseRecorder, response.WebhookResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Event-UUID", testDelivery)
+	if token != "" {
+		req.Header.Set("X-Gitlab-Token", token)
+	}
+
+	rec := httptest.NewRecorder()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	New(log, cfg, handler).ServeHTTP(rec, req)
+
+	var resp response.WebhookResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+
+	return rec, resp
+}
+
+func TestNewNormalizesFixtures(t *testing.T) {
+	opened := domain.VCSEvent{
+		Provider:    provider,
+		DeliveryID:  testDelivery,
+		Action:      domain.VCSActionOpened,
+		PRID:        "gitlab-90311",
+		Repository:  "platform/billing",
+		Title:       "Fix invoice rounding",
+		AuthorID:    "u1",
+		AuthorLogin: "alice",
+		Number:      57,
+	}
+	// bob triggers these, so only the author's numeric ID is known.
+	byBob := func(action domain.VCSAction, authorId string) domain.VCSEvent {
+		event := opened
+		event.Action = action
+		event.AuthorID = authorId
+		event.AuthorLogin = "27"
+		return event
+	}
+	draft := opened
+	draft.Title = "Draft: Fix invoice rounding"
+	draft.Draft = true
+
+	tests := []struct {
+		name    string
+		fixture string
+		mapping string
+		want    domain.VCSEvent
+	}{
+		{name: "open", fixture: "merge_request_open", mapping: "alice=u1", want: opened},
+		{name: "open draft", fixture: "merge_request_open_draft", mapping: "alice=u1", want: draft},
+		{name: "update", fixture: "merge_request_update", mapping: "alice=u1", want: opened},
+		{
+			name:    "reopen",
+			fixture: "merge_request_reopen",
+			mapping: "alice=u1",
+			want:    byBob(domain.VCSActionReopened, ""),
+		},
+		{
+			name:    "close",
+			fixture: "merge_request_close",
+			mapping: "alice=u1",
+			want:    byBob(domain.VCSActionClosed, ""),
+		},
+		{
+			name:    "merge mapped by author ID",
+			fixture: "merge_request_merge",
+			mapping: "27=u1",
+			want:    byBob(domain.VCSActionMerged, "u1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, testToken, tt.mapping)
+			handler := &recordingHandler{}
+
+			rec, resp := deliver(t, cfg, handler, mergeRequest, readFixture(t, tt.fixture), testToken)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 1 {
+				t.Fatalf("handled %d events, want 1", len(handler.events))
+			}
+			if got := handler.events[0]; got != tt.want {
+				t.Errorf("event = %+v, want %+v", got, tt.want)
+			}
+			if resp.PullRequestID != tt.want.PRID || resp.Result != string(domain.VCSOutcomeCreated) {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestNewIgnoresUnsupportedDeliveries(t *testing.T) {
+	closedUpdate := bytes.Replace(readFixture(t, "merge_request_close"),
+		[]byte(`"action": "close"`), []byte(`"action": "update"`), 1)
+
+	tests := []struct {
+		name   string
+		event  string
+		body   []byte
+		reason string
+	}{
+		{
+			name:   "unsupported action",
+			event:  mergeRequest,
+			body:   readFixture(t, "merge_request_approved"),
+			reason: "unsupported action",
+		},
+		{
+			name:   "update of a closed merge request",
+			event:  mergeRequest,
+			body:   closedUpdate,
+			reason: "merge request is closed",
+		},
+		{
+			name:   "unsupported event",
+			event:  "Push Hook",
+			body:   readFixture(t, "merge_request_open"),
+			reason: "unsupported event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, testToken, "alice=u1")
+			handler := &recordingHandler{}
+
+			rec, resp := deliver(t, cfg, handler, tt.event, tt.body, testToken)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+			if resp.Result != "ignored" || resp.Reason != tt.reason {
+				t.Errorf("response = %+v, want reason %q", resp, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNewVerifiesToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		sent       string
+		want       int
+	}{
+		{name: "valid", configured: testToken, sent: testToken, want: http.StatusOK},
+		{name: "missing", configured: testToken, want: http.StatusUnauthorized},
+		{name: "wrong", configured: testToken, sent: "not-the-token", want: http.StatusUnauthorized},
+		{name: "prefix", configured: testToken, sent: testToken[:5], want: http.StatusUnauthorized},
+		{name: "not configured", configured: "", sent: "", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(t, tt.configured, "alice=u1")
+			handler := &recordingHandler{}
+
+			rec, _ := deliver(t, cfg, handler, mergeRequest, readFixture(t, "merge_request_open"), tt.sent)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK && len(handler.events) != 0 {
+				t.Errorf("handled %d events, want none", len(handler.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package webhooks holds what the VCS webhook adapters share. Each adapter
+// authenticates the delivery, decodes the provider's payload and normalizes
+// it into a domain.VCSEvent; applying the event is up to the service.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/dto/response"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// MaxPayloadSize is the largest payload accepted from a provider. GitHub
+// caps its payloads at 25MB; GitLab and Gitea stay well below.
+const MaxPayloadSize = 25 << 20
+
+const (
+	ResultIgnored = "ignored"
+	ResultPong    = "pong"
+)
+
+// unprocessableErrors are answered with 422: the PR cannot be registered
+// until users or teams are set up, so the delivery should show as failed.
+var unprocessableErrors = []error{
+	service.ErrUserNotFound,
+	service.ErrTeamNotFound,
+	service.ErrTeamRequired,
+	service.ErrNotTeamMember,
+}
+
+type VCSEventHandler interface {
+	HandleVCSEvent(ctx context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error)
+}
+
+// Apply hands the normalized event to the service and renders the outcome
+// into resp.
+func Apply(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	handler VCSEventHandler,
+	event domain.VCSEvent,
+	resp response.WebhookResponse,
+) {
+	result, err := handler.HandleVCSEvent(r.Context(), event)
+	for _, target := range unprocessableErrors {
+		if errors.Is(err, target) {
+			log.Warn("cannot create pr", sl.Err(err))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeBadRequest, target.Error()))
+
+			return
+		}
+	}
+	if err != nil {
+		log.Error("internal error", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+		return
+	}
+
+	resp.Result = string(result.Outcome)
+	resp.Reason = result.Reason
+
+	log.Info("event handled", slog.String("result", resp.Result), slog.String("reason", resp.Reason))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// Ignore acknowledges a delivery that has nothing to apply.
+func Ignore(w http.ResponseWriter, r *http.Request, log *slog.Logger, resp response.WebhookResponse, reason string) {
+	resp.Result = ResultIgnored
+	resp.Reason = reason
+
+	log.Info("event ignored", slog.String("reason", reason))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	apiErrors "github.com/moremoneymod/pr-reviewer/internal/errors"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, bearer string) (*domain.Principal, error)
+}
+
+// Authenticate requires a valid bearer token and stores the caller in the
+// request context. Safe methods need the read scope and all others the
+// write scope; routes needing more add RequireScope.
+func Authenticate(log *slog.Logger, authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "internal.api.http.middleware.Authenticate"
+
+			log := log.With(
+				slog.String("op", op),
+				slog.String("path", r.URL.Path))
+
+			bearer, ok := bearerToken(r)
+			if !ok {
+				log.Warn("missing bearer token")
+				unauthorized(w, r, "missing bearer token")
+
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), bearer)
+			if errors.Is(err, service.ErrUnauthorized) {
+				log.Warn("invalid bearer token")
+				unauthorized(w, r, "invalid or revoked token")
+
+				return
+			}
+			if err != nil {
+				log.Error("failed to authenticate", sl.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeInternalServer, "internal server error"))
+
+				return
+			}
+
+			scope := domain.ScopeWrite
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = domain.ScopeRead
+			}
+			if !principal.HasScope(scope) {
+				log.Warn("insufficient scope", slog.String("caller", principal.Name), slog.String("scope", string(scope)))
+				forbidden(w, r, scope)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireScope rejects callers without the scope. It must run after
+// Authenticate.
+func RequireScope(scope domain.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				unauthorized(w, r, "missing bearer token")
+
+				return
+			}
+			if !principal.HasScope(scope) {
+				forbidden(w, r, scope)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="pr-reviewer"`)
+	render.Status(r, http.StatusUnauthorized)
+	render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeUnauthorized, message))
+}
+
+func forbidden(w http.ResponseWriter, r *http.Request, scope domain.Scope) {
+	render.Status(r, http.StatusForbidden)
+	render.JSON(w, r, apiErrors.NewErrorResponse(apiErrors.ErrorCodeForbidden,
+		fmt.Sprintf("token lacks the %s scope", scope)))
+}
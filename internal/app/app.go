@@ -3,30 +3,143 @@ package app
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/moremoneymod/pr-reviewer/internal/app/http"
+	"github.com/moremoneymod/pr-reviewer/internal/app/worker"
+	"github.com/moremoneymod/pr-reviewer/internal/client/chat"
+	"github.com/moremoneymod/pr-reviewer/internal/client/email"
+	"github.com/moremoneymod/pr-reviewer/internal/client/github"
+	"github.com/moremoneymod/pr-reviewer/internal/client/oidc"
+	"github.com/moremoneymod/pr-reviewer/internal/client/webhook"
 	"github.com/moremoneymod/pr-reviewer/internal/config"
 	"github.com/moremoneymod/pr-reviewer/internal/repository/postgres"
 	"github.com/moremoneymod/pr-reviewer/internal/service"
 )
 
+const (
+	reviewRequestRetryInterval = 30 * time.Second
+	eventDispatchInterval      = 5 * time.Second
+	notificationInterval       = 10 * time.Second
+	overdueCheckInterval       = time.Minute
+	digestInterval             = 5 * time.Minute
+)
+
 type App struct {
-	HTTPSrv    *http.App
-	repository *postgres.Storage
+	HTTPSrv             *http.App
+	ReviewRequestWorker *worker.App
+	EventWorker         *worker.App
+	NotificationWorker  *worker.App
+	OverdueWorker       *worker.App
+	DigestWorker        *worker.App
+	repository          *postgres.Storage
 }
 
-func New(ctx context.Context, log *slog.Logger, pgConfig string, httpConfig config.HTTPConfig) *App {
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	pgConfig string,
+	httpConfig config.HTTPConfig,
+	reviewerConfig config.ReviewerConfig,
+	githubConfig config.GitHubConfig,
+	gitlabConfig config.GitLabConfig,
+	giteaConfig config.GiteaConfig,
+	notifierConfig config.NotifierConfig,
+	smtpConfig config.SMTPConfig,
+	authConfig config.AuthConfig,
+	oidcConfig config.OIDCConfig,
+) *App {
 	repository, err := postgres.New(ctx, pgConfig)
 	if err != nil {
 		panic(err)
 	}
 
-	appService := service.New(log, repository, repository, repository)
-	httpApp := http.New(log, httpConfig, appService)
+	var reviewRequesters []service.ReviewRequester
+	if githubConfig.Token() != "" {
+		reviewRequesters = append(reviewRequesters, github.NewReviewRequester(log, githubConfig))
+	}
+
+	var notifier service.Notifier
+	switch notifierConfig.Provider() {
+	case config.NotifierProviderSlack:
+		notifier = chat.NewSlackNotifier(notifierConfig)
+	case config.NotifierProviderMattermost:
+		notifier = chat.NewMattermostNotifier(notifierConfig)
+	}
+
+	var mailer service.Mailer
+	if smtpConfig.Enabled() {
+		mailer = email.NewMailer(smtpConfig)
+	}
+
+	var jwtVerifier service.JWTVerifier
+	if oidcConfig.Enabled() {
+		verifier, err := oidc.NewVerifier(ctx, oidcConfig)
+		if err != nil {
+			panic(err)
+		}
+		jwtVerifier = verifier
+	}
+
+	providers := service.Providers{
+		PR:            repository,
+		Team:          repository,
+		User:          repository,
+		Webhook:       repository,
+		ReviewRequest: repository,
+		Subscription:  repository,
+		Notification:  repository,
+		Token:         repository,
+		Audit:         repository,
+	}
+	clients := service.Clients{
+		ReviewRequesters: reviewRequesters,
+		EventSender:      webhook.NewSender(),
+		Notifier:         notifier,
+		Mailer:           mailer,
+		JWTVerifier:      jwtVerifier,
+	}
+	appService := service.New(log, providers, clients, &reviewerConfig)
+	if token := authConfig.BootstrapToken(); token != "" {
+		if err := appService.EnsureBootstrapToken(ctx, token); err != nil {
+			panic(err)
+		}
+	}
+	httpApp := http.New(log, httpConfig, githubConfig, gitlabConfig, giteaConfig, appService)
+	reviewRequestWorker := worker.New(log, "review_requests", reviewRequestRetryInterval,
+		func(ctx context.Context) error {
+			_, err := appService.RetryReviewRequests(ctx)
+			return err
+		})
+	eventWorker := worker.New(log, "events", eventDispatchInterval,
+		func(ctx context.Context) error {
+			_, err := appService.DispatchEvents(ctx)
+			return err
+		})
+	notificationWorker := worker.New(log, "notifications", notificationInterval,
+		func(ctx context.Context) error {
+			_, err := appService.SendNotifications(ctx)
+			return err
+		})
+	overdueWorker := worker.New(log, "overdue_reviews", overdueCheckInterval,
+		func(ctx context.Context) error {
+			_, err := appService.NotifyOverdueReviews(ctx)
+			return err
+		})
+	digestWorker := worker.New(log, "digests", digestInterval,
+		func(ctx context.Context) error {
+			_, err := appService.SendDigests(ctx)
+			return err
+		})
 
 	return &App{
-		HTTPSrv:    httpApp,
-		repository: repository,
+		HTTPSrv:             httpApp,
+		ReviewRequestWorker: reviewRequestWorker,
+		EventWorker:         eventWorker,
+		NotificationWorker:  notificationWorker,
+		OverdueWorker:       overdueWorker,
+		DigestWorker:        digestWorker,
+		repository:          repository,
 	}
 }
 
@@ -35,6 +148,26 @@ func (app *App) Stop(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = app.ReviewRequestWorker.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	err = app.EventWorker.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	err = app.NotificationWorker.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	err = app.OverdueWorker.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	err = app.DigestWorker.Stop(ctx)
+	if err != nil {
+		return err
+	}
 	app.repository.Close()
 	return nil
 }
@@ -7,18 +7,48 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+	auditList "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/audit/list"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/health"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/assignment_log"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/create"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/merge"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/reassign"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/review"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/pullrequest/timeline"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/statistic"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/statistic/fairness"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/statistic/latency"
+	subscriptionCreate "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/subscriptions/create"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/subscriptions/deliveries"
+	subscriptionList "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/subscriptions/list"
+	subscriptionRemove "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/subscriptions/remove"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/add"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/get"
+	teamList "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/list"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/remove"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/tree"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/team/update"
+	tokenCreate "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/tokens/create"
+	tokenList "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/tokens/list"
+	tokenRevoke "github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/tokens/revoke"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/get_review"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/get_user"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/list"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/move_team"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/send_digest"
 	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/set_active"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/set_email"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/set_role"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/set_team_role"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/users/set_working_hours"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/gitea"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/github"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/gitlab"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/middleware"
 	"github.com/moremoneymod/pr-reviewer/internal/config"
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
 	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
 type App struct {
@@ -27,9 +57,16 @@ type App struct {
 	service    *service.Service
 }
 
-func New(log *slog.Logger, httpConfig config.HTTPConfig, service *service.Service) *App {
+func New(
+	log *slog.Logger,
+	httpConfig config.HTTPConfig,
+	githubConfig config.GitHubConfig,
+	gitlabConfig config.GitLabConfig,
+	giteaConfig config.GiteaConfig,
+	service *service.Service,
+) *App {
 
-	router := setupRouter(log, service)
+	router := setupRouter(log, githubConfig, gitlabConfig, giteaConfig, service)
 
 	httpServer := &http.Server{
 		Addr:         httpConfig.Address(),
@@ -72,22 +109,68 @@ func (app *App) Run() error {
 	return nil
 }
 
-func setupRouter(log *slog.Logger, service *service.Service) *chi.Mux {
+func setupRouter(
+	log *slog.Logger,
+	githubConfig config.GitHubConfig,
+	gitlabConfig config.GitLabConfig,
+	giteaConfig config.GiteaConfig,
+	service *service.Service,
+) *chi.Mux {
 	router := chi.NewRouter()
-	router.Route("/pullRequest", func(r chi.Router) {
-		r.Post("/create", create.New(log, service))
-		r.Post("/merge", merge.New(log, service))
-		r.Post("/reassign", reassign.New(log, service))
-	})
-	router.Route("/team", func(r chi.Router) {
-		r.Post("/add", add.New(log, service))
-		r.Get("/get", get.New(log, service))
+	router.Use(middleware.RequestID)
+	router.Get("/health", health.New(log))
+	router.Route("/webhooks", func(r chi.Router) {
+		r.Post("/github", github.New(log, githubConfig, service))
+		r.Post("/gitlab", gitlab.New(log, gitlabConfig, service))
+		r.Post("/gitea", gitea.New(log, giteaConfig, service))
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Authenticate(log, service), middleware.RequireScope(domain.ScopeAdmin))
+			r.Post("/subscriptions", subscriptionCreate.New(log, service))
+			r.Get("/subscriptions", subscriptionList.New(log, service))
+			r.Delete("/subscriptions/{id}", subscriptionRemove.New(log, service))
+			r.Get("/subscriptions/{id}/deliveries", deliveries.New(log, service))
+		})
 	})
-	router.Route("/users", func(r chi.Router) {
-		r.Post("/setIsActive", set_active.New(log, service))
-		r.Get("/getReview", get_review.New(log, service))
+	router.Group(func(router chi.Router) {
+		router.Use(middleware.Authenticate(log, service))
+		router.Route("/pullRequest", func(r chi.Router) {
+			r.Post("/create", create.New(log, service))
+			r.Post("/merge", merge.New(log, service))
+			r.Post("/reassign", reassign.New(log, service))
+			r.Post("/review", review.New(log, service))
+			r.Get("/{id}/assignmentLog", assignment_log.New(log, service))
+			r.Get("/{id}/timeline", timeline.New(log, service))
+		})
+		router.Route("/team", func(r chi.Router) {
+			r.Post("/add", add.New(log, service))
+			r.Get("/get", get.New(log, service))
+			r.Get("/tree", tree.New(log, service))
+			r.Get("/list", teamList.New(log, service))
+			r.Patch("/{name}", update.New(log, service))
+			r.Delete("/{name}", remove.New(log, service))
+		})
+		router.Route("/users", func(r chi.Router) {
+			r.Post("/setIsActive", set_active.New(log, service))
+			r.Post("/setWorkingHours", set_working_hours.New(log, service))
+			r.Post("/setEmail", set_email.New(log, service))
+			r.Post("/sendDigest", send_digest.New(log, service))
+			r.Post("/moveTeam", move_team.New(log, service))
+			r.With(middleware.RequireScope(domain.ScopeAdmin)).Post("/setRole", set_role.New(log, service))
+			r.Post("/setTeamRole", set_team_role.New(log, service))
+			r.Get("/getReview", get_review.New(log, service))
+			r.Get("/list", list.New(log, service))
+			r.Get("/{id}", get_user.New(log, service))
+		})
+		router.Route("/tokens", func(r chi.Router) {
+			r.Use(middleware.RequireScope(domain.ScopeAdmin))
+			r.Post("/", tokenCreate.New(log, service))
+			r.Get("/", tokenList.New(log, service))
+			r.Delete("/{id}", tokenRevoke.New(log, service))
+		})
+		router.Get("/statistics", statistic.New(log, service))
+		router.Get("/statistics/latency", latency.New(log, service))
+		router.Get("/statistics/fairness", fairness.New(log, service))
+		router.With(middleware.RequireScope(domain.ScopeAdmin)).Get("/audit", auditList.New(log, service))
 	})
-	router.Get("/health", health.New(log))
-	router.Get("/statistics", statistic.New(log, service))
 	return router
 }
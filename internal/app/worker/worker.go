@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+)
+
+// App runs a job periodically until it is stopped. A failed run is logged
+// and the job runs again on the next tick.
+type App struct {
+	log      *slog.Logger
+	name     string
+	interval time.Duration
+	job      func(ctx context.Context) error
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func New(log *slog.Logger, name string, interval time.Duration, job func(ctx context.Context) error) *App {
+	return &App{
+		log:      log,
+		name:     name,
+		interval: interval,
+		job:      job,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run blocks until Stop is called or the context is done.
+func (app *App) Run(ctx context.Context) {
+	const op = "internal.app.worker.Run"
+
+	log := app.log.With(
+		slog.String("op", op),
+		slog.String("worker", app.name))
+
+	defer close(app.done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-app.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Info("starting worker", slog.Duration("interval", app.interval))
+
+	ticker := time.NewTicker(app.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := app.job(ctx); err != nil && ctx.Err() == nil {
+			log.Error("worker run failed", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("stopped worker")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop cancels the current run and waits for the worker to return.
+func (app *App) Stop(ctx context.Context) error {
+	close(app.stop)
+
+	select {
+	case <-app.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,12 @@
+package chat
+
+import "github.com/moremoneymod/pr-reviewer/internal/config"
+
+// NewMattermostNotifier posts to a Mattermost incoming webhook. Handles are
+// usernames and channels are channel names, e.g. town-square.
+func NewMattermostNotifier(cfg config.NotifierConfig) *Notifier {
+	return newNotifier(cfg, markup{
+		mention: func(handle string) string { return "@" + handle },
+		bold:    func(text string) string { return "**" + text + "**" },
+	})
+}
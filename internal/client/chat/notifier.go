@@ -0,0 +1,112 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const sendTimeout = 10 * time.Second
+
+// markup is how a chat formats messages.
+type markup struct {
+	// mention addresses the user with the given handle.
+	mention func(handle string) string
+	// bold wraps text in bold.
+	bold func(text string) string
+}
+
+// Notifier posts notifications through a chat incoming webhook to the
+// channel configured for the PR's team. Reviewers are mentioned by their
+// chat handle, or named by user ID when they have none.
+type Notifier struct {
+	httpClient *http.Client
+	cfg        config.NotifierConfig
+	markup     markup
+}
+
+func newNotifier(cfg config.NotifierConfig, markup markup) *Notifier {
+	return &Notifier{
+		httpClient: &http.Client{Timeout: sendTimeout},
+		cfg:        cfg,
+		markup:     markup,
+	}
+}
+
+type messageBody struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Notify treats any 2xx response as posted. Rate limits and server errors
+// may be retried; other failures wrap service.ErrNotificationRejected.
+func (n *Notifier) Notify(ctx context.Context, notification domain.Notification) error {
+	const op = "internal.client.chat.Notify"
+
+	body, err := json.Marshal(messageBody{
+		Channel: n.cfg.Channel(notification.TeamName),
+		Text:    n.text(notification),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.WebhookURL(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("%s: chat responded %s: %s", op, resp.Status, strings.TrimSpace(string(message)))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", service.ErrNotificationRejected, err)
+}
+
+func (n *Notifier) text(notification domain.Notification) string {
+	reviewer := notification.UserID
+	if handle, ok := n.cfg.Handle(notification.UserID); ok {
+		reviewer = n.markup.mention(handle)
+	}
+	pr := fmt.Sprintf("%s (`%s`)", n.markup.bold(notification.PRName), notification.PRID)
+
+	switch notification.Kind {
+	case domain.NotificationReassigned:
+		return fmt.Sprintf("%s, you have been assigned to review %s in place of %s.",
+			reviewer, pr, notification.ReplacedUserID)
+	case domain.NotificationOverdue:
+		return fmt.Sprintf("%s, your review of %s is overdue: it has waited %s of your working hours.",
+			reviewer, pr, formatWait(notification.Wait))
+	default:
+		return fmt.Sprintf("%s, you have been assigned to review %s.", reviewer, pr)
+	}
+}
+
+// formatWait rounds the wait to minutes, e.g. 9h5m.
+func formatWait(wait time.Duration) string {
+	wait = max(wait.Round(time.Minute), time.Minute)
+	return strings.TrimSuffix(wait.String(), "0s")
+}
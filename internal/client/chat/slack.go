@@ -0,0 +1,13 @@
+package chat
+
+import "github.com/moremoneymod/pr-reviewer/internal/config"
+
+// NewSlackNotifier posts to a Slack incoming webhook. Handles are Slack
+// member IDs, e.g. U024BE7LH. The channel override only works with legacy
+// webhooks; app webhooks always post to the channel they were created for.
+func NewSlackNotifier(cfg config.NotifierConfig) *Notifier {
+	return newNotifier(cfg, markup{
+		mention: func(handle string) string { return "<@" + handle + ">" },
+		bold:    func(text string) string { return "*" + text + "*" },
+	})
+}
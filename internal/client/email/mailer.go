@@ -0,0 +1,228 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	digestHTML = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/digest.html"))
+	digestText = texttemplate.Must(texttemplate.ParseFS(templates, "templates/digest.txt"))
+)
+
+// Mailer sends email through an SMTP server. The connection is upgraded
+// with STARTTLS when the server offers it, and authenticates with PLAIN
+// when a username is configured, which outside localhost requires TLS.
+type Mailer struct {
+	cfg config.SMTPConfig
+}
+
+func NewMailer(cfg config.SMTPConfig) *Mailer {
+	return &Mailer{cfg: cfg}
+}
+
+type digestData struct {
+	Username string
+	Reviews  []digestReview
+	Overdue  int
+}
+
+type digestReview struct {
+	ID       string
+	Name     string
+	AuthorID string
+	Wait     string
+	Overdue  bool
+}
+
+// SendDigest emails the digest as text with an HTML alternative.
+func (m *Mailer) SendDigest(ctx context.Context, digest domain.Digest) error {
+	const op = "internal.client.email.SendDigest"
+
+	data := digestData{
+		Username: digest.Username,
+		Reviews:  make([]digestReview, len(digest.Reviews)),
+		Overdue:  digest.OverdueCount(),
+	}
+	if data.Username == "" {
+		data.Username = digest.UserID
+	}
+	for i, review := range digest.Reviews {
+		data.Reviews[i] = digestReview{
+			ID:       review.ID,
+			Name:     review.Name,
+			AuthorID: review.AuthorID,
+			Wait:     formatWait(review.BusinessWait),
+			Overdue:  review.Overdue,
+		}
+	}
+
+	var text, html bytes.Buffer
+	if err := digestText.Execute(&text, data); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := digestHTML.Execute(&html, data); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	to := mail.Address{Name: digest.Username, Address: digest.Email}
+	message, err := m.message(to, digestSubject(data), text.Bytes(), html.Bytes(), digest.GeneratedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := m.send(ctx, digest.Email, message); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func digestSubject(data digestData) string {
+	switch {
+	case len(data.Reviews) == 0:
+		return "Your reviews: nothing pending"
+	case data.Overdue > 0:
+		return fmt.Sprintf("Your reviews: %d pending, %d overdue", len(data.Reviews), data.Overdue)
+	default:
+		return fmt.Sprintf("Your reviews: %d pending", len(data.Reviews))
+	}
+}
+
+// message builds a multipart/alternative message; clients show the last
+// part they can render, so the HTML part goes last.
+func (m *Mailer) message(to mail.Address, subject string, text []byte, html []byte, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := [][2]string{
+		{"From", m.cfg.From()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+// send delivers the message within the context's deadline.
+func (m *Mailer) send(ctx context.Context, to string, message []byte) error {
+	from, err := mail.ParseAddress(m.cfg.From())
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.cfg.Address())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host()}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username() != "" {
+		auth := smtp.PlainAuth("", m.cfg.Username(), m.cfg.Password(), m.cfg.Host())
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// formatWait rounds the wait to minutes, e.g. 9h5m.
+func formatWait(wait time.Duration) string {
+	wait = wait.Round(time.Minute)
+	if wait < time.Minute {
+		return "under a minute"
+	}
+
+	return strings.TrimSuffix(wait.String(), "0s")
+}
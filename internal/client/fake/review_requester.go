@@ -0,0 +1,57 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// ReviewRequester records review requests in memory instead of calling a
+// code host. SetErr makes calls fail, e.g. to exercise the retry queue.
+type ReviewRequester struct {
+	mu       sync.Mutex
+	provider string
+	requests []domain.ReviewRequest
+	err      error
+}
+
+func NewReviewRequester(provider string) *ReviewRequester {
+	return &ReviewRequester{provider: provider}
+}
+
+func (r *ReviewRequester) Provider() string {
+	return r.provider
+}
+
+func (r *ReviewRequester) RequestReviewers(ctx context.Context, request domain.ReviewRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	request.Reviewers = slices.Clone(request.Reviewers)
+	request.Removed = slices.Clone(request.Removed)
+	r.requests = append(r.requests, request)
+
+	return nil
+}
+
+// SetErr changes the error returned by later calls; nil makes them succeed.
+func (r *ReviewRequester) SetErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
+
+// Requests returns the requests recorded so far.
+func (r *ReviewRequester) Requests() []domain.ReviewRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.requests)
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	provider = "github"
+
+	maxAttempts  = 3
+	retryBackoff = 250 * time.Millisecond
+	apiVersion   = "2022-11-28"
+)
+
+// ReviewRequester requests reviews on GitHub pull requests through the REST
+// API. Reviewers without a GitHub login in the user mapping are skipped.
+type ReviewRequester struct {
+	log        *slog.Logger
+	httpClient *http.Client
+	cfg        config.GitHubConfig
+}
+
+func NewReviewRequester(log *slog.Logger, cfg config.GitHubConfig) *ReviewRequester {
+	return &ReviewRequester{
+		log:        log,
+		httpClient: &http.Client{},
+		cfg:        cfg,
+	}
+}
+
+func (r *ReviewRequester) Provider() string {
+	return provider
+}
+
+// RequestReviewers withdraws the review requests of the removed reviewers
+// and requests reviews from the new ones. Failures the API may recover from
+// are retried a few times with backoff; rejections wrap
+// service.ErrReviewRequestRejected.
+func (r *ReviewRequester) RequestReviewers(ctx context.Context, request domain.ReviewRequest) error {
+	const op = "internal.client.github.RequestReviewers"
+
+	path := fmt.Sprintf("/repos/%s/pulls/%d/requested_reviewers", request.Source.Repository, request.Source.Number)
+
+	if removed := r.logins(request.Removed); len(removed) > 0 {
+		if err := r.do(ctx, http.MethodDelete, path, removed); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if reviewers := r.logins(request.Reviewers); len(reviewers) > 0 {
+		if err := r.do(ctx, http.MethodPost, path, reviewers); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ReviewRequester) logins(userIds []string) []string {
+	logins := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		login, ok := r.cfg.Login(userId)
+		if !ok {
+			r.log.Warn("no github login for reviewer", slog.String("userId", userId))
+			continue
+		}
+
+		logins = append(logins, login)
+	}
+
+	return logins
+}
+
+type reviewersBody struct {
+	Reviewers []string `json:"reviewers"`
+}
+
+// do sends the request, retrying network errors, rate limits and server
+// errors.
+func (r *ReviewRequester) do(ctx context.Context, method string, path string, reviewers []string) error {
+	body, err := json.Marshal(reviewersBody{Reviewers: reviewers})
+	if err != nil {
+		return err
+	}
+
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		wait, err := r.send(ctx, method, path, body)
+		if err == nil || wait < 0 || attempt == maxAttempts {
+			return err
+		}
+
+		wait = max(wait, backoff)
+		backoff *= 2
+
+		r.log.Warn("github request failed, retrying",
+			slog.String("method", method),
+			slog.String("path", path),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait),
+			slog.String("error", err.Error()))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single call. A failure comes with how long to wait before
+// retrying, which is negative when retrying cannot help.
+func (r *ReviewRequester) send(ctx context.Context, method string, path string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.cfg.APIURL()+path, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+r.cfg.Token())
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(message)))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return retryAfter(resp.Header), err
+	case resp.StatusCode >= 500:
+		return 0, err
+	default:
+		return -1, fmt.Errorf("%w: %w", service.ErrReviewRequestRejected, err)
+	}
+}
+
+// retryAfter reads how long GitHub asks to wait. Waits beyond the caller's
+// deadline end the call, and the request is retried from the queue.
+func retryAfter(header http.Header) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return max(time.Until(time.Unix(reset, 0)), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,172 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+)
+
+const (
+	fetchTimeout = 10 * time.Second
+	// keysTTL is how long fetched keys are used before they are refreshed.
+	keysTTL = time.Hour
+	// minRefreshInterval limits fetches, so that forged tokens or an
+	// unavailable identity provider cannot make us hammer it.
+	minRefreshInterval = time.Minute
+	// leeway tolerates clock skew between us and the identity provider.
+	leeway      = time.Minute
+	maxJWKSSize = 1 << 20
+)
+
+// Verifier validates JWTs issued by the OIDC identity provider against its
+// JWKS. Keys from a URL are cached and refetched hourly or when a token is
+// signed with an unknown key; a JWKS file is read once.
+type Verifier struct {
+	httpClient *http.Client
+	cfg        config.OIDCConfig
+
+	mu          sync.Mutex
+	keys        auth.JWKS
+	loadedAt    time.Time
+	attemptedAt time.Time
+}
+
+// NewVerifier loads the JWKS, so that a misconfigured identity provider is
+// caught on startup.
+func NewVerifier(ctx context.Context, cfg config.OIDCConfig) (*Verifier, error) {
+	const op = "internal.client.oidc.NewVerifier"
+
+	verifier := &Verifier{
+		httpClient: &http.Client{Timeout: fetchTimeout},
+		cfg:        cfg,
+	}
+
+	if err := verifier.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return verifier, nil
+}
+
+// VerifyJWT returns the subject of a valid token. Tokens that fail
+// validation wrap service.ErrUnauthorized.
+func (v *Verifier) VerifyJWT(ctx context.Context, token string) (string, error) {
+	const op = "internal.client.oidc.VerifyJWT"
+
+	policy := auth.JWTPolicy{
+		Issuer:   v.cfg.Issuer(),
+		Audience: v.cfg.Audience(),
+		Leeway:   leeway,
+	}
+
+	claims, err := auth.VerifyJWT(token, v.currentKeys(ctx, false), policy, time.Now())
+	if errors.Is(err, auth.ErrUnknownKey) {
+		// The identity provider may have rotated its keys.
+		claims, err = auth.VerifyJWT(token, v.currentKeys(ctx, true), policy, time.Now())
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %w", op, service.ErrUnauthorized, err)
+	}
+
+	return claims.Subject, nil
+}
+
+// currentKeys refetches keys from the URL when they are older than keysTTL,
+// or right away when unknownKey is set. A failed fetch keeps the previous
+// keys until the next attempt. The fetch runs without mu held, so other
+// requests keep verifying with the previous keys meanwhile.
+func (v *Verifier) currentKeys(ctx context.Context, unknownKey bool) auth.JWKS {
+	v.mu.Lock()
+	stale := unknownKey || time.Since(v.loadedAt) > keysTTL
+	refetch := v.cfg.JWKSURL() != "" && stale && time.Since(v.attemptedAt) > minRefreshInterval
+	if refetch {
+		v.attemptedAt = time.Now()
+	}
+	keys := v.keys
+	v.mu.Unlock()
+
+	if !refetch {
+		return keys
+	}
+
+	fetched, err := v.load(ctx)
+	if err != nil {
+		return keys
+	}
+	v.setKeys(fetched)
+
+	return fetched
+}
+
+func (v *Verifier) refresh(ctx context.Context) error {
+	keys, err := v.load(ctx)
+	if err != nil {
+		return err
+	}
+	v.setKeys(keys)
+
+	return nil
+}
+
+func (v *Verifier) setKeys(keys auth.JWKS) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.keys = keys
+	v.loadedAt = time.Now()
+}
+
+// load reads the JWKS from the file or the URL.
+func (v *Verifier) load(ctx context.Context) (auth.JWKS, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path := v.cfg.JWKSFile(); path != "" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = v.fetch(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load jwks: %w", err)
+	}
+
+	keys, err := auth.ParseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing keys")
+	}
+
+	return keys, nil
+}
+
+func (v *Verifier) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
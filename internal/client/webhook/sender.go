@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+	userAgent   = "pr-reviewer-webhooks"
+)
+
+// Sender posts events to subscriber URLs as JSON. The body is signed with
+// the subscription secret in X-PR-Reviewer-Signature-256 the way GitHub
+// signs its webhooks: "sha256=" followed by the hex HMAC-SHA256.
+// X-PR-Reviewer-Delivery stays the same across retries of a delivery.
+type Sender struct {
+	httpClient *http.Client
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		httpClient: &http.Client{Timeout: sendTimeout},
+	}
+}
+
+type eventBody struct {
+	ID         int64         `json:"id"`
+	Type       string        `json:"type"`
+	OccurredAt string        `json:"occurred_at"`
+	Data       eventBodyData `json:"data"`
+}
+
+type eventBodyData struct {
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Reviewers       []string `json:"reviewers"`
+	OldReviewerID   string   `json:"old_reviewer_id,omitempty"`
+	NewReviewerID   string   `json:"new_reviewer_id,omitempty"`
+}
+
+// Send treats any 2xx response as delivered.
+func (s *Sender) Send(ctx context.Context, delivery *domain.SubscriptionDelivery) (int, error) {
+	const op = "internal.client.webhook.Send"
+
+	event := delivery.Event
+	reviewers := event.Reviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+
+	body, err := json.Marshal(eventBody{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		OccurredAt: event.CreatedAt.Format(time.RFC3339),
+		Data: eventBodyData{
+			PullRequestID:   event.PRID,
+			PullRequestName: event.PRName,
+			AuthorID:        event.AuthorID,
+			Reviewers:       reviewers,
+			OldReviewerID:   event.OldReviewerID,
+			NewReviewerID:   event.NewReviewerID,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-PR-Reviewer-Event", string(event.Type))
+	req.Header.Set("X-PR-Reviewer-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-PR-Reviewer-Signature-256", "sha256="+signature.SignSHA256(delivery.Subscription.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s: subscriber responded %s", op, resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+const authBootstrapTokenName = "AUTH_BOOTSTRAP_TOKEN"
+
+const minBootstrapTokenLength = 16
+
+type AuthConfig struct {
+	bootstrapToken string
+}
+
+func NewAuthConfig() (AuthConfig, error) {
+	token := os.Getenv(authBootstrapTokenName)
+	if len(token) > 0 && len(token) < minBootstrapTokenLength {
+		return AuthConfig{}, fmt.Errorf("%s: must be at least %d characters", authBootstrapTokenName, minBootstrapTokenLength)
+	}
+
+	return AuthConfig{
+		bootstrapToken: token,
+	}, nil
+}
+
+// BootstrapToken returns the admin token registered on startup so that the
+// first tokens can be issued. Empty skips the bootstrap.
+func (cfg *AuthConfig) BootstrapToken() string {
+	return cfg.bootstrapToken
+}
@@ -3,8 +3,16 @@ package config
 import "github.com/joho/godotenv"
 
 type Config struct {
-	HTTPConfig HTTPConfig
-	PGConfig   PGConfig
+	HTTPConfig     HTTPConfig
+	PGConfig       PGConfig
+	ReviewerConfig ReviewerConfig
+	GitHubConfig   GitHubConfig
+	GitLabConfig   GitLabConfig
+	GiteaConfig    GiteaConfig
+	NotifierConfig NotifierConfig
+	SMTPConfig     SMTPConfig
+	AuthConfig     AuthConfig
+	OIDCConfig     OIDCConfig
 }
 
 func Load(path string) error {
@@ -31,9 +39,49 @@ func MustLoad(path string) *Config {
 	if err != nil {
 		panic(err)
 	}
+	reviewerConfig, err := NewReviewerConfig()
+	if err != nil {
+		panic(err)
+	}
+	githubConfig, err := NewGitHubConfig()
+	if err != nil {
+		panic(err)
+	}
+	gitlabConfig, err := NewGitLabConfig()
+	if err != nil {
+		panic(err)
+	}
+	giteaConfig, err := NewGiteaConfig()
+	if err != nil {
+		panic(err)
+	}
+	notifierConfig, err := NewNotifierConfig()
+	if err != nil {
+		panic(err)
+	}
+	smtpConfig, err := NewSMTPConfig()
+	if err != nil {
+		panic(err)
+	}
+	authConfig, err := NewAuthConfig()
+	if err != nil {
+		panic(err)
+	}
+	oidcConfig, err := NewOIDCConfig()
+	if err != nil {
+		panic(err)
+	}
 
 	return &Config{
-		HTTPConfig: httpConfig,
-		PGConfig:   pgConfig,
+		HTTPConfig:     httpConfig,
+		PGConfig:       pgConfig,
+		ReviewerConfig: reviewerConfig,
+		GitHubConfig:   githubConfig,
+		GitLabConfig:   gitlabConfig,
+		GiteaConfig:    giteaConfig,
+		NotifierConfig: notifierConfig,
+		SMTPConfig:     smtpConfig,
+		AuthConfig:     authConfig,
+		OIDCConfig:     oidcConfig,
 	}
 }
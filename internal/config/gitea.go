@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	giteaWebhookSecretName = "GITEA_WEBHOOK_SECRET"
+	giteaUserMappingName   = "GITEA_USER_MAPPING"
+)
+
+type GiteaConfig struct {
+	webhookSecret string
+	users         map[string]string
+}
+
+func NewGiteaConfig() (GiteaConfig, error) {
+	users, err := parseUserMapping(giteaUserMappingName)
+	if err != nil {
+		return GiteaConfig{}, err
+	}
+
+	return GiteaConfig{
+		webhookSecret: os.Getenv(giteaWebhookSecretName),
+		users:         users,
+	}, nil
+}
+
+// WebhookSecret returns the secret Gitea signs webhook payloads with. Empty
+// disables the webhook.
+func (cfg *GiteaConfig) WebhookSecret() string {
+	return cfg.webhookSecret
+}
+
+// UserID maps a Gitea login to a user ID. Logins are case-insensitive.
+func (cfg *GiteaConfig) UserID(login string) (string, bool) {
+	userId, ok := cfg.users[strings.ToLower(login)]
+	return userId, ok
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	githubWebhookSecretName = "GITHUB_WEBHOOK_SECRET"
+	githubUserMappingName   = "GITHUB_USER_MAPPING"
+	githubTokenName         = "GITHUB_TOKEN"
+	githubAPIURLName        = "GITHUB_API_URL"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+type GitHubConfig struct {
+	webhookSecret string
+	token         string
+	apiURL        string
+	users         map[string]string
+	logins        map[string]string
+}
+
+func NewGitHubConfig() (GitHubConfig, error) {
+	users, err := parseUserMapping(githubUserMappingName)
+	if err != nil {
+		return GitHubConfig{}, err
+	}
+
+	logins := make(map[string]string, len(users))
+	for login, userId := range users {
+		logins[userId] = login
+	}
+
+	apiURL := strings.TrimSuffix(os.Getenv(githubAPIURLName), "/")
+	if len(apiURL) == 0 {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	return GitHubConfig{
+		webhookSecret: os.Getenv(githubWebhookSecretName),
+		token:         os.Getenv(githubTokenName),
+		apiURL:        apiURL,
+		users:         users,
+		logins:        logins,
+	}, nil
+}
+
+// WebhookSecret returns the secret GitHub signs webhook payloads with. Empty
+// disables the webhook.
+func (cfg *GitHubConfig) WebhookSecret() string {
+	return cfg.webhookSecret
+}
+
+// Token returns the token used to call the GitHub REST API. Empty disables
+// pushing reviewers to GitHub.
+func (cfg *GitHubConfig) Token() string {
+	return cfg.token
+}
+
+// APIURL returns the GitHub REST API base URL without a trailing slash.
+func (cfg *GitHubConfig) APIURL() string {
+	return cfg.apiURL
+}
+
+// UserID maps a GitHub login to a user ID. Logins are case-insensitive.
+func (cfg *GitHubConfig) UserID(login string) (string, bool) {
+	userId, ok := cfg.users[strings.ToLower(login)]
+	return userId, ok
+}
+
+// Login maps a user ID back to their GitHub login, lower-cased.
+func (cfg *GitHubConfig) Login(userId string) (string, bool) {
+	login, ok := cfg.logins[userId]
+	return login, ok
+}
+
+// parseUserMapping reads a comma-separated list of login=user_id pairs.
+// Logins are lower-cased.
+func parseUserMapping(envName string) (map[string]string, error) {
+	pairs, err := parsePairs(envName, "login=user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]string, len(pairs))
+	for login, userId := range pairs {
+		users[strings.ToLower(login)] = userId
+	}
+
+	return users, nil
+}
+
+// parsePairs reads a comma-separated list of key=value pairs; format names
+// them in the error.
+func parsePairs(envName string, format string) (map[string]string, error) {
+	pairs := make(map[string]string)
+
+	value := os.Getenv(envName)
+	if len(value) == 0 {
+		return pairs, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%s: invalid mapping %q, expected %s", envName, pair, format)
+		}
+
+		pairs[key] = value
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	gitlabWebhookTokenName = "GITLAB_WEBHOOK_TOKEN"
+	gitlabUserMappingName  = "GITLAB_USER_MAPPING"
+)
+
+type GitLabConfig struct {
+	webhookToken string
+	users        map[string]string
+}
+
+func NewGitLabConfig() (GitLabConfig, error) {
+	users, err := parseUserMapping(gitlabUserMappingName)
+	if err != nil {
+		return GitLabConfig{}, err
+	}
+
+	return GitLabConfig{
+		webhookToken: os.Getenv(gitlabWebhookTokenName),
+		users:        users,
+	}, nil
+}
+
+// WebhookToken returns the secret token GitLab sends with every webhook.
+// Empty disables the webhook.
+func (cfg *GitLabConfig) WebhookToken() string {
+	return cfg.webhookToken
+}
+
+// UserID maps a GitLab username or numeric user ID to a user ID. Usernames
+// are case-insensitive.
+func (cfg *GitLabConfig) UserID(username string) (string, bool) {
+	userId, ok := cfg.users[strings.ToLower(username)]
+	return userId, ok
+}
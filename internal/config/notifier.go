@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	notifierProviderName       = "NOTIFIER_PROVIDER"
+	notifierWebhookURLName     = "NOTIFIER_WEBHOOK_URL"
+	notifierDefaultChannelName = "NOTIFIER_DEFAULT_CHANNEL"
+	notifierTeamChannelsName   = "NOTIFIER_TEAM_CHANNELS"
+	notifierUserHandlesName    = "NOTIFIER_USER_HANDLES"
+)
+
+const (
+	NotifierProviderSlack      = "slack"
+	NotifierProviderMattermost = "mattermost"
+)
+
+type NotifierConfig struct {
+	provider       string
+	webhookURL     string
+	defaultChannel string
+	teamChannels   map[string]string
+	userHandles    map[string]string
+}
+
+func NewNotifierConfig() (NotifierConfig, error) {
+	provider := strings.ToLower(os.Getenv(notifierProviderName))
+	switch provider {
+	case "", NotifierProviderSlack, NotifierProviderMattermost:
+	default:
+		return NotifierConfig{}, fmt.Errorf("%s: unknown provider %q", notifierProviderName, provider)
+	}
+
+	webhookURL := os.Getenv(notifierWebhookURLName)
+	if provider != "" && webhookURL == "" {
+		return NotifierConfig{}, fmt.Errorf("%s is required for %s", notifierWebhookURLName, provider)
+	}
+
+	teamChannels, err := parsePairs(notifierTeamChannelsName, "team_name=channel")
+	if err != nil {
+		return NotifierConfig{}, err
+	}
+
+	userHandles, err := parsePairs(notifierUserHandlesName, "user_id=handle")
+	if err != nil {
+		return NotifierConfig{}, err
+	}
+	for userId, handle := range userHandles {
+		userHandles[userId] = strings.TrimPrefix(handle, "@")
+	}
+
+	return NotifierConfig{
+		provider:       provider,
+		webhookURL:     webhookURL,
+		defaultChannel: os.Getenv(notifierDefaultChannelName),
+		teamChannels:   teamChannels,
+		userHandles:    userHandles,
+	}, nil
+}
+
+// Provider returns the chat the notifications are posted to: slack or
+// mattermost. Empty disables notifications.
+func (cfg *NotifierConfig) Provider() string {
+	return cfg.provider
+}
+
+// WebhookURL returns the incoming webhook URL notifications are posted to.
+func (cfg *NotifierConfig) WebhookURL() string {
+	return cfg.webhookURL
+}
+
+// Channel returns the channel for the team's notifications, falling back to
+// the default channel. Empty leaves the channel to the webhook.
+func (cfg *NotifierConfig) Channel(teamName string) string {
+	if channel, ok := cfg.teamChannels[teamName]; ok {
+		return channel
+	}
+
+	return cfg.defaultChannel
+}
+
+// Handle maps a user ID to their chat handle: the member ID on Slack, the
+// username without the leading @ on Mattermost.
+func (cfg *NotifierConfig) Handle(userId string) (string, bool) {
+	handle, ok := cfg.userHandles[userId]
+	return handle, ok
+}
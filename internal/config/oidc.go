@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	oidcIssuerName   = "OIDC_ISSUER"
+	oidcAudienceName = "OIDC_AUDIENCE"
+	oidcJWKSURLName  = "OIDC_JWKS_URL"
+	oidcJWKSFileName = "OIDC_JWKS_FILE"
+)
+
+type OIDCConfig struct {
+	issuer   string
+	audience string
+	jwksURL  string
+	jwksFile string
+}
+
+func NewOIDCConfig() (OIDCConfig, error) {
+	jwksURL := os.Getenv(oidcJWKSURLName)
+	jwksFile := os.Getenv(oidcJWKSFileName)
+	if jwksURL == "" && jwksFile == "" {
+		return OIDCConfig{}, nil
+	}
+	if jwksURL != "" && jwksFile != "" {
+		return OIDCConfig{}, fmt.Errorf("only one of %s and %s may be set", oidcJWKSURLName, oidcJWKSFileName)
+	}
+
+	issuer := os.Getenv(oidcIssuerName)
+	if issuer == "" {
+		return OIDCConfig{}, errors.New("oidc issuer not found")
+	}
+
+	return OIDCConfig{
+		issuer:   issuer,
+		audience: os.Getenv(oidcAudienceName),
+		jwksURL:  jwksURL,
+		jwksFile: jwksFile,
+	}, nil
+}
+
+// Enabled reports whether a JWKS is configured. Without one only API tokens
+// are accepted.
+func (cfg *OIDCConfig) Enabled() bool {
+	return cfg.jwksURL != "" || cfg.jwksFile != ""
+}
+
+// Issuer returns the required iss claim.
+func (cfg *OIDCConfig) Issuer() string {
+	return cfg.issuer
+}
+
+// Audience returns the required aud claim. Empty accepts any audience.
+func (cfg *OIDCConfig) Audience() string {
+	return cfg.audience
+}
+
+// JWKSURL returns where the identity provider publishes its signing keys.
+func (cfg *OIDCConfig) JWKSURL() string {
+	return cfg.jwksURL
+}
+
+// JWKSFile returns a local JWKS used instead of JWKSURL, e.g. in offline
+// tests.
+func (cfg *OIDCConfig) JWKSFile() string {
+	return cfg.jwksFile
+}
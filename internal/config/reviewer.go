@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	reviewerPairingWindowName = "REVIEWER_PAIRING_WINDOW"
+	reviewSLAName             = "REVIEW_SLA"
+)
+
+type ReviewerConfig struct {
+	pairingWindow time.Duration
+	reviewSLA     time.Duration
+}
+
+func NewReviewerConfig() (ReviewerConfig, error) {
+	pairingWindow, err := parseOptionalDuration(reviewerPairingWindowName)
+	if err != nil {
+		return ReviewerConfig{}, err
+	}
+
+	reviewSLA, err := parseOptionalDuration(reviewSLAName)
+	if err != nil {
+		return ReviewerConfig{}, err
+	}
+
+	return ReviewerConfig{pairingWindow: pairingWindow, reviewSLA: reviewSLA}, nil
+}
+
+// PairingWindow returns how far back reviewer/author pairings are counted.
+// Zero disables the anti-affinity weighting.
+func (cfg *ReviewerConfig) PairingWindow() time.Duration {
+	return cfg.pairingWindow
+}
+
+// ReviewSLA returns how many of the reviewer's business hours a review may
+// wait before it is overdue. Zero disables the SLA.
+func (cfg *ReviewerConfig) ReviewSLA() time.Duration {
+	return cfg.reviewSLA
+}
+
+func parseOptionalDuration(envName string) (time.Duration, error) {
+	value := os.Getenv(envName)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	return time.ParseDuration(value)
+}
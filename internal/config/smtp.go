@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"os"
+	"strconv"
+)
+
+const (
+	smtpHostName     = "SMTP_HOST"
+	smtpPortName     = "SMTP_PORT"
+	smtpUsernameName = "SMTP_USERNAME"
+	smtpPasswordName = "SMTP_PASSWORD"
+	smtpFromName     = "SMTP_FROM"
+)
+
+const defaultSMTPPort = "587"
+
+type SMTPConfig struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPConfig() (SMTPConfig, error) {
+	host := os.Getenv(smtpHostName)
+	if len(host) == 0 {
+		return SMTPConfig{}, nil
+	}
+
+	port := os.Getenv(smtpPortName)
+	if len(port) == 0 {
+		port = defaultSMTPPort
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return SMTPConfig{}, fmt.Errorf("%s: invalid port %q", smtpPortName, port)
+	}
+
+	from := os.Getenv(smtpFromName)
+	if len(from) == 0 {
+		return SMTPConfig{}, errors.New("smtp from address not found")
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return SMTPConfig{}, fmt.Errorf("%s: %w", smtpFromName, err)
+	}
+
+	return SMTPConfig{
+		host:     host,
+		port:     port,
+		username: os.Getenv(smtpUsernameName),
+		password: os.Getenv(smtpPasswordName),
+		from:     from,
+	}, nil
+}
+
+// Enabled reports whether an SMTP server is configured. Without one the
+// email digest is disabled.
+func (cfg *SMTPConfig) Enabled() bool {
+	return cfg.host != ""
+}
+
+func (cfg *SMTPConfig) Host() string {
+	return cfg.host
+}
+
+func (cfg *SMTPConfig) Address() string {
+	return net.JoinHostPort(cfg.host, cfg.port)
+}
+
+// Username returns the user to authenticate as. Empty skips
+// authentication.
+func (cfg *SMTPConfig) Username() string {
+	return cfg.username
+}
+
+func (cfg *SMTPConfig) Password() string {
+	return cfg.password
+}
+
+// From returns the sender address, optionally with a display name, e.g.
+// "PR Reviewer <reviews@example.com>".
+func (cfg *SMTPConfig) From() string {
+	return cfg.from
+}
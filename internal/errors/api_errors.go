@@ -13,10 +13,16 @@ const (
 	ErrorCodeTeamExists     ErrorCode = "TEAM_EXISTS"
 	ErrorCodePRExists       ErrorCode = "PR_EXISTS"
 	ErrorCodePRMerged       ErrorCode = "PR_MERGED"
+	ErrorCodePRClosed       ErrorCode = "PR_CLOSED"
 	ErrorCodeNotAssigned    ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeAssigned       ErrorCode = "ALREADY_ASSIGNED"
 	ErrorCodeNoCandidate    ErrorCode = "NO_CANDIDATE"
+	ErrorCodeOpenReviews    ErrorCode = "OPEN_REVIEWS"
+	ErrorCodeOpenPRs        ErrorCode = "OPEN_PRS"
+	ErrorCodeTeamCycle      ErrorCode = "TEAM_CYCLE"
 	ErrorCodeNotFound       ErrorCode = "NOT_FOUND"
 	ErrorCodeUnauthorized   ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden      ErrorCode = "FORBIDDEN"
 	ErrorCodeBadRequest     ErrorCode = "BAD_REQUEST"
 	ErrorCodeInternalServer ErrorCode = "INTERNAL_SERVER_ERROR"
 )
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedJWT     = errors.New("malformed JWT")
+	ErrUnsupportedAlg   = errors.New("unsupported JWT algorithm")
+	ErrUnknownKey       = errors.New("unknown JWT signing key")
+	ErrInvalidSignature = errors.New("invalid JWT signature")
+	ErrInvalidClaims    = errors.New("invalid JWT claims")
+)
+
+// Supported signing algorithms.
+const (
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+)
+
+// JWKS is a set of public signing keys by key id.
+type JWKS map[string]crypto.PublicKey
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS reads the RSA and P-256 signing keys of a JSON Web Key Set.
+// Encryption keys and keys of other types are skipped.
+func ParseJWKS(data []byte) (JWKS, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(JWKS, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		var (
+			publicKey crypto.PublicKey
+			err       error
+		)
+		switch key.Kty {
+		case "RSA":
+			publicKey, err = rsaKey(key)
+		case "EC":
+			if key.Crv != "P-256" {
+				continue
+			}
+			publicKey, err = ecKey(key)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwk %q: %w", key.Kid, err)
+		}
+
+		keys[key.Kid] = publicKey
+	}
+
+	return keys, nil
+}
+
+func rsaKey(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func ecKey(key jwk) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	if len(x) != 32 || len(y) != 32 {
+		return nil, errors.New("invalid P-256 coordinates")
+	}
+
+	point := append(append([]byte{4}, x...), y...)
+	return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+}
+
+// Claims are the registered claims checked by VerifyJWT.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+}
+
+// Audience may be a single string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+// JWTPolicy is what a token must satisfy besides a valid signature. Empty
+// Audience accepts any audience.
+type JWTPolicy struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// VerifyJWT checks the signature of a compact JWS against the keys and the
+// registered claims against the policy. Tokens must carry an expiry and a
+// subject.
+func VerifyJWT(token string, keys JWKS, policy JWTPolicy, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedJWT
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+		Typ string `json:"typ"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformedJWT, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrMalformedJWT, err)
+	}
+
+	key, ok := keys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, header.Kid)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	switch header.Alg {
+	case AlgRS256:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: key %q is not an RSA key", ErrUnsupportedAlg, header.Kid)
+		}
+		if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) != nil {
+			return nil, ErrInvalidSignature
+		}
+	case AlgES256:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: key %q is not an EC key", ErrUnsupportedAlg, header.Kid)
+		}
+		if len(signature) != 64 {
+			return nil, ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return nil, ErrInvalidSignature
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, header.Alg)
+	}
+
+	var payload struct {
+		Sub string   `json:"sub"`
+		Iss string   `json:"iss"`
+		Aud audience `json:"aud"`
+		Exp *int64   `json:"exp"`
+		Nbf *int64   `json:"nbf"`
+	}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, fmt.Errorf("%w: payload: %w", ErrMalformedJWT, err)
+	}
+
+	claims := &Claims{
+		Subject:  payload.Sub,
+		Issuer:   payload.Iss,
+		Audience: payload.Aud,
+	}
+	if payload.Exp == nil {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidClaims)
+	}
+	claims.ExpiresAt = time.Unix(*payload.Exp, 0)
+	if payload.Nbf != nil {
+		claims.NotBefore = time.Unix(*payload.Nbf, 0)
+	}
+
+	switch {
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidClaims)
+	case claims.Issuer != policy.Issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidClaims, claims.Issuer)
+	case policy.Audience != "" && !slices.Contains(claims.Audience, policy.Audience):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidClaims)
+	case !now.Before(claims.ExpiresAt.Add(policy.Leeway)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidClaims)
+	case now.Add(policy.Leeway).Before(claims.NotBefore):
+		return nil, fmt.Errorf("%w: token not valid yet", ErrInvalidClaims)
+	}
+
+	return claims, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,337 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://id.example.com"
+	testAudience = "pr-reviewer"
+	rsaKid       = "rsa-1"
+	ecKid        = "ec-1"
+)
+
+var testNow = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+type testKeys struct {
+	rsa  *rsa.PrivateKey
+	ec   *ecdsa.PrivateKey
+	jwks JWKS
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// newTestKeys generates an RSA and a P-256 key and reads them back through
+// ParseJWKS, as the verifier would.
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+	point, err := ecKey.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("encode ec key: %v", err)
+	}
+
+	set := map[string]any{"keys": []map[string]string{
+		{
+			"kty": "RSA",
+			"kid": rsaKid,
+			"use": "sig",
+			"n":   b64(rsaKey.N.Bytes()),
+			"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{"kty": "EC", "kid": ecKid, "crv": "P-256", "x": b64(point[1:33]), "y": b64(point[33:])},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("encode jwks: %v", err)
+	}
+	jwks, err := ParseJWKS(data)
+	if err != nil {
+		t.Fatalf("ParseJWKS: %v", err)
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey, jwks: jwks}
+}
+
+// sign builds a compact JWS. The signing key follows alg: RS256 and ES256
+// use the test keys, HS256 uses a shared secret and none leaves the
+// signature empty.
+func (k testKeys) sign(t *testing.T, header map[string]any, claims map[string]any) string {
+	t.Helper()
+
+	encode := func(v map[string]any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("encode segment: %v", err)
+		}
+		return b64(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch header["alg"] {
+	case AlgRS256:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("sign RS256: %v", err)
+		}
+	case AlgES256:
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		if err != nil {
+			t.Fatalf("sign ES256: %v", err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	case "HS256":
+		mac := hmac.New(sha256.New, []byte("shared-secret"))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	}
+
+	return signingInput + "." + b64(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "u1",
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": testNow.Add(time.Hour).Unix(),
+		"nbf": testNow.Add(-time.Hour).Unix(),
+	}
+}
+
+func TestVerifyJWT(t *testing.T) {
+	keys := newTestKeys(t)
+	policy := JWTPolicy{Issuer: testIssuer, Audience: testAudience, Leeway: time.Minute}
+
+	rs256 := map[string]any{"alg": AlgRS256, "kid": rsaKid, "typ": "JWT"}
+	es256 := map[string]any{"alg": AlgES256, "kid": ecKid, "typ": "JWT"}
+	header := func(alg string, kid string) map[string]any {
+		return map[string]any{"alg": alg, "kid": kid, "typ": "JWT"}
+	}
+	claims := func(change func(claims map[string]any)) map[string]any {
+		c := validClaims()
+		change(c)
+		return c
+	}
+
+	// tamper swaps the payload of a validly signed token.
+	tamper := func(token string) string {
+		parts := strings.Split(token, ".")
+		forged, _ := json.Marshal(claims(func(c map[string]any) { c["sub"] = "admin" }))
+		return parts[0] + "." + b64(forged) + "." + parts[2]
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		policy  JWTPolicy
+		wantErr error
+	}{
+		{name: "valid RS256", token: keys.sign(t, rs256, validClaims())},
+		{name: "valid ES256", token: keys.sign(t, es256, validClaims())},
+		{name: "tampered RS256 payload", token: tamper(keys.sign(t, rs256, validClaims())), wantErr: ErrInvalidSignature},
+		{name: "tampered ES256 payload", token: tamper(keys.sign(t, es256, validClaims())), wantErr: ErrInvalidSignature},
+		{name: "alg none", token: keys.sign(t, header("none", rsaKid), validClaims()), wantErr: ErrUnsupportedAlg},
+		{name: "alg HS256", token: keys.sign(t, header("HS256", rsaKid), validClaims()), wantErr: ErrUnsupportedAlg},
+		{name: "unknown kid", token: keys.sign(t, header(AlgRS256, "rotated"), validClaims()), wantErr: ErrUnknownKey},
+		{name: "RSA key with ES256", token: keys.sign(t, header(AlgES256, rsaKid), validClaims()), wantErr: ErrUnsupportedAlg},
+		{name: "EC key with RS256", token: keys.sign(t, header(AlgRS256, ecKid), validClaims()), wantErr: ErrUnsupportedAlg},
+		{
+			name: "expired within leeway",
+			token: keys.sign(t, rs256, claims(func(c map[string]any) {
+				c["exp"] = testNow.Add(-30 * time.Second).Unix()
+			})),
+		},
+		{
+			name: "expired",
+			token: keys.sign(t, rs256, claims(func(c map[string]any) {
+				c["exp"] = testNow.Add(-2 * time.Minute).Unix()
+			})),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name: "not yet valid within leeway",
+			token: keys.sign(t, rs256, claims(func(c map[string]any) {
+				c["nbf"] = testNow.Add(30 * time.Second).Unix()
+			})),
+		},
+		{
+			name: "not yet valid",
+			token: keys.sign(t, rs256, claims(func(c map[string]any) {
+				c["nbf"] = testNow.Add(2 * time.Minute).Unix()
+			})),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name:    "wrong issuer",
+			token:   keys.sign(t, rs256, claims(func(c map[string]any) { c["iss"] = "https://evil.example.com" })),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name:  "audience list",
+			token: keys.sign(t, rs256, claims(func(c map[string]any) { c["aud"] = []string{"other", testAudience} })),
+		},
+		{
+			name:    "audience list without ours",
+			token:   keys.sign(t, rs256, claims(func(c map[string]any) { c["aud"] = []string{"other"} })),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name:    "other audience",
+			token:   keys.sign(t, rs256, claims(func(c map[string]any) { c["aud"] = "other" })),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name:   "any audience",
+			token:  keys.sign(t, rs256, claims(func(c map[string]any) { c["aud"] = "other" })),
+			policy: JWTPolicy{Issuer: testIssuer, Leeway: time.Minute},
+		},
+		{
+			name:    "missing exp",
+			token:   keys.sign(t, rs256, claims(func(c map[string]any) { delete(c, "exp") })),
+			wantErr: ErrInvalidClaims,
+		},
+		{
+			name:    "missing sub",
+			token:   keys.sign(t, rs256, claims(func(c map[string]any) { delete(c, "sub") })),
+			wantErr: ErrInvalidClaims,
+		},
+		{name: "not a JWS", token: "not.a-token", wantErr: ErrMalformedJWT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			if tt.policy != (JWTPolicy{}) {
+				p = tt.policy
+			}
+
+			got, err := VerifyJWT(tt.token, keys.jwks, p, testNow)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if got.Subject != "u1" || got.Issuer != testIssuer {
+				t.Errorf("claims = %+v", got)
+			}
+		})
+	}
+}
+
+func TestVerifyJWTReadsAudience(t *testing.T) {
+	keys := newTestKeys(t)
+	policy := JWTPolicy{Issuer: testIssuer, Audience: testAudience}
+	rs256 := map[string]any{"alg": AlgRS256, "kid": rsaKid}
+
+	tests := []struct {
+		name string
+		aud  any
+		want []string
+	}{
+		{name: "string", aud: testAudience, want: []string{testAudience}},
+		{name: "list", aud: []string{testAudience, "other"}, want: []string{testAudience, "other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validClaims()
+			c["aud"] = tt.aud
+
+			got, err := VerifyJWT(keys.sign(t, rs256, c), keys.jwks, policy, testNow)
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !slices.Equal(got.Audience, tt.want) {
+				t.Errorf("audience = %v, want %v", got.Audience, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name     string
+		jwks     string
+		wantKids []string
+		wantErr  bool
+	}{
+		{
+			name: "skips encryption keys and other key types",
+			jwks: `{"keys":[
+				{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"},
+				{"kty":"oct","kid":"hmac","k":"c2VjcmV0"},
+				{"kty":"EC","kid":"p384","crv":"P-384","x":"AA","y":"AA"}
+			]}`,
+			wantKids: []string{},
+		},
+		{
+			name:    "rejects a tiny exponent",
+			jwks:    `{"keys":[{"kty":"RSA","kid":"weak","n":"AQAB","e":"AQ"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "rejects short P-256 coordinates",
+			jwks:    `{"keys":[{"kty":"EC","kid":"short","crv":"P-256","x":"AA","y":"AA"}]}`,
+			wantErr: true,
+		},
+		{name: "rejects invalid JSON", jwks: `{"keys":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseJWKS([]byte(tt.jwks))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseJWKS = %v, want an error", keys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+
+			kids := make([]string, 0, len(keys))
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			slices.Sort(kids)
+			if !slices.Equal(kids, tt.wantKids) {
+				t.Errorf("kids = %v, want %v", kids, tt.wantKids)
+			}
+		})
+	}
+}
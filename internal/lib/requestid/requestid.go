@@ -0,0 +1,34 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header carries the request ID from and back to the client.
+const Header = "X-Request-ID"
+
+// MaxLength caps request IDs accepted from clients.
+const MaxLength = 100
+
+// New returns a random request ID.
+func New() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+type requestIdKey struct{}
+
+// With returns a copy of ctx carrying the request ID.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+// FromContext returns the request ID, or empty outside of a request.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
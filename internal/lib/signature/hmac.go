@@ -0,0 +1,37 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// SignSHA256 returns the hex-encoded HMAC-SHA256 of the body under the
+// secret.
+func SignSHA256(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySHA256 reports whether signature is the hex-encoded HMAC-SHA256 of
+// the body under the secret. The comparison runs in constant time.
+func VerifySHA256(secret string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// VerifyToken reports whether the token equals the expected shared secret.
+// The comparison runs in constant time.
+func VerifyToken(expected string, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"time"
+
 	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
 	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
@@ -10,17 +12,135 @@ func ToDomainPRFromEntity(PREntity *entity.PR) *domain.PR {
 		ID:        PREntity.ID,
 		Name:      PREntity.Name,
 		AuthorID:  PREntity.AuthorID,
+		MergedBy:  PREntity.MergedBy,
 		Status:    StringToPRStatus(PREntity.Status),
 		Reviewers: PREntity.Reviewers,
+		TeamID:    PREntity.TeamID,
 		CreatedAt: &PREntity.CreatedAt,
 		MergedAt:  PREntity.MergedAt,
+		ClosedAt:  PREntity.ClosedAt,
+		Source:    ToDomainPRSourceFromEntity(PREntity),
+	}
+}
+
+func ToDomainPRSourceFromEntity(PREntity *entity.PR) *domain.PRSource {
+	if PREntity.SourceProvider == nil {
+		return nil
+	}
+
+	source := &domain.PRSource{Provider: *PREntity.SourceProvider}
+	if PREntity.SourceRepository != nil {
+		source.Repository = *PREntity.SourceRepository
+	}
+	if PREntity.SourceNumber != nil {
+		source.Number = *PREntity.SourceNumber
+	}
+
+	return source
+}
+
+func ToDomainReviewRequestsFromEntity(requestsEntity []*entity.ReviewRequest) []*domain.QueuedReviewRequest {
+	requests := make([]*domain.QueuedReviewRequest, len(requestsEntity))
+	for i, request := range requestsEntity {
+		requests[i] = &domain.QueuedReviewRequest{
+			ReviewRequest: domain.ReviewRequest{
+				PRID: request.PRID,
+				Source: domain.PRSource{
+					Provider:   request.SourceProvider,
+					Repository: request.SourceRepository,
+					Number:     request.SourceNumber,
+				},
+				Reviewers: request.Reviewers,
+				Removed:   request.Removed,
+			},
+			NextAttemptAt: request.NextAttemptAt,
+			FailedAt:      request.FailedAt,
+			ID:            request.ID,
+			Attempts:      request.Attempts,
+		}
+		if request.LastError != nil {
+			requests[i].LastError = *request.LastError
+		}
+	}
+
+	return requests
+}
+
+func ToDomainNotificationsFromEntity(notificationsEntity []*entity.Notification) []*domain.QueuedNotification {
+	notifications := make([]*domain.QueuedNotification, len(notificationsEntity))
+	for i, notification := range notificationsEntity {
+		notifications[i] = &domain.QueuedNotification{
+			Notification: domain.Notification{
+				Kind:           domain.NotificationKind(notification.Kind),
+				PRID:           notification.PRID,
+				PRName:         notification.PRName,
+				TeamName:       notification.TeamName,
+				UserID:         notification.UserID,
+				ReplacedUserID: notification.ReplacedUserID,
+				Wait:           time.Duration(notification.WaitSeconds) * time.Second,
+			},
+			NextAttemptAt: notification.NextAttemptAt,
+			FailedAt:      notification.FailedAt,
+			ID:            notification.ID,
+			Attempts:      notification.Attempts,
+		}
+		if notification.LastError != nil {
+			notifications[i].LastError = *notification.LastError
+		}
+	}
+
+	return notifications
+}
+
+func ToDomainOpenReviewsFromEntity(reviewsEntity []*entity.OpenReview) []*domain.OpenReview {
+	reviews := make([]*domain.OpenReview, len(reviewsEntity))
+	for i, review := range reviewsEntity {
+		reviews[i] = &domain.OpenReview{
+			AssignedAt: review.AssignedAt,
+			PRID:       review.PRID,
+			PRName:     review.PRName,
+			TeamName:   review.TeamName,
+			UserID:     review.UserID,
+			TeamID:     review.TeamID,
+		}
+	}
+
+	return reviews
+}
+
+func ToDomainAPITokenFromEntity(tokenEntity *entity.APIToken) *domain.APIToken {
+	scopes := make([]domain.Scope, len(tokenEntity.Scopes))
+	for i, scope := range tokenEntity.Scopes {
+		scopes[i] = domain.Scope(scope)
+	}
+
+	return &domain.APIToken{
+		CreatedAt:  tokenEntity.CreatedAt,
+		LastUsedAt: tokenEntity.LastUsedAt,
+		RevokedAt:  tokenEntity.RevokedAt,
+		Name:       tokenEntity.Name,
+		Prefix:     tokenEntity.Prefix,
+		Scopes:     scopes,
+		ID:         tokenEntity.ID,
+	}
+}
+
+func ToDomainAPITokensFromEntity(tokensEntity []*entity.APIToken) []*domain.APIToken {
+	tokens := make([]*domain.APIToken, len(tokensEntity))
+	for i, token := range tokensEntity {
+		tokens[i] = ToDomainAPITokenFromEntity(token)
 	}
+
+	return tokens
 }
 
 func ToDomainTeamFromEntity(teamEntity *entity.Team) *domain.Team {
 	team := &domain.Team{
-		ID:   teamEntity.ID,
-		Name: teamEntity.Name,
+		ID:         teamEntity.ID,
+		Name:       teamEntity.Name,
+		ParentID:   teamEntity.ParentID,
+		ParentName: teamEntity.ParentName,
+		Settings:   ToDomainTeamSettingsFromEntity(teamEntity),
 	}
 
 	team.Members = make([]domain.Member, len(teamEntity.Members))
@@ -32,10 +152,76 @@ func ToDomainTeamFromEntity(teamEntity *entity.Team) *domain.Team {
 	return team
 }
 
+func ToDomainTeamSummaryFromEntity(summaryEntity *entity.TeamSummary) *domain.Team {
+	team := ToDomainTeamFromEntity(&summaryEntity.Team)
+	team.MemberCount = summaryEntity.MemberCount
+	team.ActiveMemberCount = summaryEntity.ActiveMemberCount
+
+	return team
+}
+
+func ToDomainTeamSettingsFromEntity(teamEntity *entity.Team) domain.TeamSettings {
+	var settings domain.TeamSettings
+
+	if teamEntity.ReviewSLAMinutes != nil {
+		sla := time.Duration(*teamEntity.ReviewSLAMinutes) * time.Minute
+		settings.ReviewSLA = &sla
+	}
+	if teamEntity.ReviewerFallback != nil {
+		fallback := domain.ReviewerFallback(*teamEntity.ReviewerFallback)
+		settings.ReviewerFallback = &fallback
+	}
+
+	return settings
+}
+
+// ToEntityTeamSettingsFromDomain returns the review SLA in minutes and the
+// reviewer fallback, nil when inherited.
+func ToEntityTeamSettingsFromDomain(settings domain.TeamSettings) (*int, *string) {
+	var (
+		slaMinutes *int
+		fallback   *string
+	)
+
+	if settings.ReviewSLA != nil {
+		minutes := int(settings.ReviewSLA.Minutes())
+		slaMinutes = &minutes
+	}
+	if settings.ReviewerFallback != nil {
+		value := string(*settings.ReviewerFallback)
+		fallback = &value
+	}
+
+	return slaMinutes, fallback
+}
+
+func ToDomainTeamNodesFromEntity(nodesEntity []*entity.TeamNode) []*domain.TeamNode {
+	nodes := make([]*domain.TeamNode, len(nodesEntity))
+	for i, node := range nodesEntity {
+		nodes[i] = &domain.TeamNode{
+			ID:               node.ID,
+			Name:             node.Name,
+			ParentID:         node.ParentID,
+			MemberCount:      node.MemberCount,
+			TotalMemberCount: node.TotalMemberCount,
+			OpenReviews:      node.OpenReviews,
+			TotalOpenReviews: node.TotalOpenReviews,
+		}
+	}
+
+	return nodes
+}
+
 func ToDomainMemberFromEntity(memberEntity entity.Member) domain.Member {
 	return domain.Member{
 		UserID:   memberEntity.UserID,
 		Username: memberEntity.Username,
+		Teams:    ToDomainMembershipsFromEntity(memberEntity.Teams),
+		WorkingHours: domain.WorkingHours{
+			Timezone:  memberEntity.Timezone,
+			StartHour: memberEntity.WorkStart,
+			EndHour:   memberEntity.WorkEnd,
+		},
 		TeamID:   memberEntity.TeamID,
 		IsActive: memberEntity.IsActive,
 	}
@@ -45,10 +231,12 @@ func ToDomainPRShortsFromEntity(PRsEntity []*entity.PRShort) []*domain.PRShort {
 	prShorts := make([]*domain.PRShort, len(PRsEntity))
 	for i, pr := range PRsEntity {
 		prShorts[i] = &domain.PRShort{
-			ID:       pr.ID,
-			Name:     pr.Name,
-			AuthorID: pr.AuthorID,
-			Status:   pr.Status,
+			AssignedAt: pr.AssignedAt,
+			ID:         pr.ID,
+			Name:       pr.Name,
+			AuthorID:   pr.AuthorID,
+			Status:     pr.Status,
+			TeamID:     pr.TeamID,
 		}
 	}
 
@@ -59,10 +247,51 @@ func ToDomainUserFromEntity(userEntity *entity.User) *domain.User {
 	return &domain.User{
 		ID:       userEntity.ID,
 		Username: userEntity.Username,
-		TeamID:   userEntity.TeamID,
-		TeamName: userEntity.TeamName,
-		IsActive: userEntity.IsActive,
+		Teams:    ToDomainMembershipsFromEntity(userEntity.Teams),
+		WorkingHours: domain.WorkingHours{
+			Timezone:  userEntity.Timezone,
+			StartHour: userEntity.WorkStart,
+			EndHour:   userEntity.WorkEnd,
+		},
+		Role:          domain.Role(userEntity.Role),
+		OpenReviews:   userEntity.OpenReviews,
+		IsActive:      userEntity.IsActive,
+		Email:         userEntity.Email,
+		DigestEnabled: userEntity.DigestEnabled,
+	}
+}
+
+func ToDomainDigestRecipientsFromEntity(recipientsEntity []*entity.DigestRecipient) []*domain.DigestRecipient {
+	recipients := make([]*domain.DigestRecipient, len(recipientsEntity))
+	for i, recipient := range recipientsEntity {
+		recipients[i] = &domain.DigestRecipient{
+			SentAt:   recipient.SentAt,
+			UserID:   recipient.ID,
+			Username: recipient.Username,
+			Email:    recipient.Email,
+			WorkingHours: domain.WorkingHours{
+				Timezone:  recipient.Timezone,
+				StartHour: recipient.WorkStart,
+				EndHour:   recipient.WorkEnd,
+			},
+			DigestEnabled: recipient.DigestEnabled,
+		}
+	}
+
+	return recipients
+}
+
+func ToDomainMembershipsFromEntity(membershipsEntity []entity.TeamMembership) []domain.TeamMembership {
+	memberships := make([]domain.TeamMembership, len(membershipsEntity))
+	for i, membership := range membershipsEntity {
+		memberships[i] = domain.TeamMembership{
+			TeamID:   membership.TeamID,
+			TeamName: membership.TeamName,
+			Role:     domain.Role(membership.Role),
+		}
 	}
+
+	return memberships
 }
 
 func ToDomainMembersFromEntity(membersEntity []entity.Member) []domain.Member {
@@ -123,6 +352,8 @@ func StringToPRStatus(status string) domain.PRStatus {
 		return domain.PRStatusOpen
 	case "MERGED":
 		return domain.PRStatusMerged
+	case "CLOSED":
+		return domain.PRStatusClosed
 	default:
 		return domain.PRStatusOpen
 	}
@@ -134,7 +365,224 @@ func PRStatusToString(status domain.PRStatus) string {
 		return "OPEN"
 	case domain.PRStatusMerged:
 		return "MERGED"
+	case domain.PRStatusClosed:
+		return "CLOSED"
 	default:
 		return "OPEN"
 	}
 }
+
+func ToEntityAssignmentLogFromDomain(decision *domain.AssignmentDecision) *entity.AssignmentLog {
+	exclusions := make([]entity.AssignmentExclusion, len(decision.Exclusions))
+	for i, exclusion := range decision.Exclusions {
+		exclusions[i] = entity.AssignmentExclusion{
+			UserID: exclusion.UserID,
+			Reason: exclusion.Reason,
+		}
+	}
+
+	scores := make([]entity.AssignmentScore, len(decision.Scores))
+	for i, score := range decision.Scores {
+		scores[i] = entity.AssignmentScore{
+			UserID:         score.UserID,
+			Pairings:       score.Pairings,
+			Weight:         score.Weight,
+			InWorkingHours: score.InWorkingHours,
+		}
+	}
+
+	return &entity.AssignmentLog{
+		PRID:       decision.PRID,
+		Trigger:    decision.Trigger,
+		Strategy:   decision.Strategy,
+		ActorID:    decision.ActorID,
+		Seed:       decision.Seed,
+		Candidates: nonNilStrings(decision.Candidates),
+		Exclusions: exclusions,
+		Scores:     scores,
+		Chosen:     nonNilStrings(decision.Chosen),
+		TeamID:     decision.TeamID,
+	}
+}
+
+func ToDomainAssignmentLogFromEntity(logEntity *entity.AssignmentLog) *domain.AssignmentDecision {
+	exclusions := make([]domain.AssignmentExclusion, len(logEntity.Exclusions))
+	for i, exclusion := range logEntity.Exclusions {
+		exclusions[i] = domain.AssignmentExclusion{
+			UserID: exclusion.UserID,
+			Reason: exclusion.Reason,
+		}
+	}
+
+	scores := make([]domain.AssignmentScore, len(logEntity.Scores))
+	for i, score := range logEntity.Scores {
+		scores[i] = domain.AssignmentScore{
+			UserID:         score.UserID,
+			Pairings:       score.Pairings,
+			Weight:         score.Weight,
+			InWorkingHours: score.InWorkingHours,
+		}
+	}
+
+	return &domain.AssignmentDecision{
+		CreatedAt:  &logEntity.CreatedAt,
+		PRID:       logEntity.PRID,
+		Trigger:    logEntity.Trigger,
+		ActorID:    logEntity.ActorID,
+		Strategy:   logEntity.Strategy,
+		Seed:       logEntity.Seed,
+		Candidates: logEntity.Candidates,
+		Exclusions: exclusions,
+		Scores:     scores,
+		Chosen:     logEntity.Chosen,
+		TeamID:     logEntity.TeamID,
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func ToEntityOutboxPayloadFromDomain(event *domain.OutboxEvent) entity.OutboxPayload {
+	return entity.OutboxPayload{
+		PRName:        event.PRName,
+		AuthorID:      event.AuthorID,
+		Reviewers:     nonNilStrings(event.Reviewers),
+		OldReviewerID: event.OldReviewerID,
+		NewReviewerID: event.NewReviewerID,
+	}
+}
+
+func ToDomainSubscriptionFromEntity(subscriptionEntity *entity.Subscription) *domain.Subscription {
+	eventTypes := make([]domain.EventType, len(subscriptionEntity.EventTypes))
+	for i, eventType := range subscriptionEntity.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	return &domain.Subscription{
+		CreatedAt:  &subscriptionEntity.CreatedAt,
+		URL:        subscriptionEntity.URL,
+		Secret:     subscriptionEntity.Secret,
+		EventTypes: eventTypes,
+		ID:         subscriptionEntity.ID,
+	}
+}
+
+func ToDomainSubscriptionsFromEntity(subscriptionsEntity []*entity.Subscription) []*domain.Subscription {
+	subscriptions := make([]*domain.Subscription, len(subscriptionsEntity))
+	for i, subscription := range subscriptionsEntity {
+		subscriptions[i] = ToDomainSubscriptionFromEntity(subscription)
+	}
+
+	return subscriptions
+}
+
+func ToDomainSubscriptionDeliveriesFromEntity(
+	deliveriesEntity []*entity.SubscriptionDelivery,
+) []*domain.SubscriptionDelivery {
+	deliveries := make([]*domain.SubscriptionDelivery, len(deliveriesEntity))
+	for i, delivery := range deliveriesEntity {
+		deliveries[i] = &domain.SubscriptionDelivery{
+			NextAttemptAt: delivery.NextAttemptAt,
+			CreatedAt:     &delivery.CreatedAt,
+			DeliveredAt:   delivery.DeliveredAt,
+			Subscription: domain.Subscription{
+				URL:    delivery.SubscriptionURL,
+				Secret: delivery.SubscriptionSecret,
+				ID:     delivery.SubscriptionID,
+			},
+			Event: domain.OutboxEvent{
+				CreatedAt:     delivery.EventCreatedAt,
+				Type:          domain.EventType(delivery.EventType),
+				PRID:          delivery.EventPRID,
+				PRName:        delivery.EventPayload.PRName,
+				AuthorID:      delivery.EventPayload.AuthorID,
+				OldReviewerID: delivery.EventPayload.OldReviewerID,
+				NewReviewerID: delivery.EventPayload.NewReviewerID,
+				Reviewers:     delivery.EventPayload.Reviewers,
+				ID:            delivery.EventID,
+			},
+			Status:   domain.DeliveryStatus(delivery.Status),
+			ID:       delivery.ID,
+			Attempts: delivery.Attempts,
+		}
+		if delivery.LastStatusCode != nil {
+			deliveries[i].LastStatusCode = *delivery.LastStatusCode
+		}
+		if delivery.LastError != nil {
+			deliveries[i].LastError = *delivery.LastError
+		}
+	}
+
+	return deliveries
+}
+
+func ToDomainAuditEventsFromEntity(eventsEntity []*entity.AuditEvent) []*domain.AuditEvent {
+	events := make([]*domain.AuditEvent, len(eventsEntity))
+	for i, event := range eventsEntity {
+		diff := make(map[string]domain.AuditChange, len(event.Diff))
+		for field, change := range event.Diff {
+			diff[field] = domain.AuditChange{Before: change.Before, After: change.After}
+		}
+
+		events[i] = &domain.AuditEvent{
+			CreatedAt:  event.CreatedAt,
+			Actor:      event.Actor,
+			Action:     event.Action,
+			EntityType: domain.AuditEntity(event.EntityType),
+			EntityID:   event.EntityID,
+			RequestID:  event.RequestID,
+			Diff:       diff,
+			ID:         event.ID,
+		}
+	}
+
+	return events
+}
+
+func ToDomainPREventsFromEntity(eventsEntity []*entity.PREvent) []*domain.PREvent {
+	events := make([]*domain.PREvent, len(eventsEntity))
+	for i, event := range eventsEntity {
+		events[i] = &domain.PREvent{
+			CreatedAt: event.CreatedAt,
+			Type:      domain.PREventType(event.Type),
+			Actor:     event.Actor,
+			UserID:    event.UserID,
+			Reason:    event.Reason,
+			Decision:  domain.ReviewDecision(event.Decision),
+			ID:        event.ID,
+		}
+	}
+
+	return events
+}
+
+func ToDomainLatencyFromEntity(row entity.LatencyRow) domain.Latency {
+	latency := domain.Latency{Count: row.Count}
+	if row.MedianSeconds != nil {
+		latency.Median = time.Duration(*row.MedianSeconds * float64(time.Second))
+	}
+	if row.P90Seconds != nil {
+		latency.P90 = time.Duration(*row.P90Seconds * float64(time.Second))
+	}
+
+	return latency
+}
+
+func ToDomainStatisticsPointsFromEntity(pointsEntity []entity.StatisticsPoint) []domain.StatisticsPoint {
+	points := make([]domain.StatisticsPoint, len(pointsEntity))
+	for i, point := range pointsEntity {
+		points[i] = domain.StatisticsPoint{
+			Start:       point.Start,
+			CreatedPRs:  point.CreatedPRs,
+			MergedPRs:   point.MergedPRs,
+			Assignments: point.Assignments,
+		}
+	}
+
+	return points
+}
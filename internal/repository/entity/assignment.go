@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type AssignmentLog struct {
+	CreatedAt  time.Time             `db:"created_at"`
+	PRID       string                `db:"pr_id"`
+	Trigger    string                `db:"trigger"`
+	Strategy   string                `db:"strategy"`
+	ActorID    string                `db:"actor_id"`
+	Candidates []string              `db:"candidates"`
+	Exclusions []AssignmentExclusion `db:"exclusions"`
+	Scores     []AssignmentScore     `db:"scores"`
+	Chosen     []string              `db:"chosen"`
+	ID         int64                 `db:"id"`
+	Seed       int64                 `db:"seed"`
+	TeamID     int                   `db:"team_id"`
+}
+
+type AssignmentExclusion struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type AssignmentScore struct {
+	UserID         string  `json:"user_id"`
+	Pairings       int     `json:"pairings"`
+	Weight         float64 `json:"weight"`
+	InWorkingHours bool    `json:"in_working_hours"`
+}
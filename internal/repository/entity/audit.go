@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type AuditEvent struct {
+	CreatedAt  time.Time              `db:"created_at"`
+	Actor      string                 `db:"actor"`
+	Action     string                 `db:"action"`
+	EntityType string                 `db:"entity_type"`
+	EntityID   string                 `db:"entity_id"`
+	RequestID  string                 `db:"request_id"`
+	Diff       map[string]AuditChange `db:"diff"`
+	ID         int64                  `db:"id"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type APIToken struct {
+	CreatedAt  *time.Time `db:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	Scopes     []string   `db:"scopes"`
+	ID         int64      `db:"id"`
+}
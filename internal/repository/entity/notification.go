@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+type Notification struct {
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	FailedAt       *time.Time `db:"failed_at"`
+	LastError      *string    `db:"last_error"`
+	Kind           string     `db:"kind"`
+	PRID           string     `db:"pr_id"`
+	PRName         string     `db:"pr_name"`
+	TeamName       string     `db:"team_name"`
+	UserID         string     `db:"user_id"`
+	ReplacedUserID string     `db:"replaced_user_id"`
+	ID             int64      `db:"id"`
+	WaitSeconds    int64      `db:"wait_seconds"`
+	Attempts       int        `db:"attempts"`
+}
+
+type OpenReview struct {
+	AssignedAt time.Time `db:"assigned_at"`
+	PRID       string    `db:"pr_id"`
+	PRName     string    `db:"pr_name"`
+	TeamName   string    `db:"team_name"`
+	UserID     string    `db:"user_id"`
+	TeamID     int       `db:"team_id"`
+}
@@ -3,18 +3,50 @@ package entity
 import "time"
 
 type PR struct {
-	CreatedAt time.Time  `db:"created_at"`
-	MergedAt  *time.Time `db:"merged_at"`
-	ID        string     `db:"id"`
-	Name      string     `db:"name"`
-	AuthorID  string     `db:"author_id"`
-	Status    string     `db:"status"`
-	Reviewers []string   `db:"-"`
+	CreatedAt        time.Time  `db:"created_at"`
+	MergedAt         *time.Time `db:"merged_at"`
+	ClosedAt         *time.Time `db:"closed_at"`
+	SourceProvider   *string    `db:"source_provider"`
+	SourceRepository *string    `db:"source_repository"`
+	SourceNumber     *int       `db:"source_number"`
+	ID               string     `db:"id"`
+	Name             string     `db:"name"`
+	AuthorID         string     `db:"author_id"`
+	MergedBy         string     `db:"merged_by"`
+	Status           string     `db:"status"`
+	Reviewers        []string   `db:"-"`
+	TeamID           int        `db:"team_id"`
+}
+
+type ReviewRequest struct {
+	NextAttemptAt    time.Time  `db:"next_attempt_at"`
+	FailedAt         *time.Time `db:"failed_at"`
+	LastError        *string    `db:"last_error"`
+	PRID             string     `db:"pr_id"`
+	SourceProvider   string     `db:"source_provider"`
+	SourceRepository string     `db:"source_repository"`
+	Reviewers        []string   `db:"reviewers"`
+	Removed          []string   `db:"removed"`
+	ID               int64      `db:"id"`
+	SourceNumber     int        `db:"source_number"`
+	Attempts         int        `db:"attempts"`
 }
 
 type PRShort struct {
-	ID       string `db:"id"`
-	Name     string `db:"name"`
-	AuthorID string `db:"author_id"`
-	Status   string `db:"status"`
+	AssignedAt *time.Time `db:"assigned_at"`
+	ID         string     `db:"id"`
+	Name       string     `db:"name"`
+	AuthorID   string     `db:"author_id"`
+	Status     string     `db:"status"`
+	TeamID     int        `db:"team_id"`
+}
+
+type Pairing struct {
+	PartnerID string `db:"partner_id"`
+	Pairings  int    `db:"pairings"`
+}
+
+type Reviewer struct {
+	PRID   string `db:"pr_id"`
+	UserID string `db:"user_id"`
 }
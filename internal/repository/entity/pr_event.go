@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type PREvent struct {
+	CreatedAt time.Time `db:"created_at"`
+	Type      string    `db:"type"`
+	Actor     string    `db:"actor"`
+	UserID    string    `db:"user_id"`
+	Reason    string    `db:"reason"`
+	Decision  string    `db:"decision"`
+	ID        int64     `db:"id"`
+}
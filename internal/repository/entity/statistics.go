@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type UserAssignmentStatistics struct {
 	UserID            string `db:"user_id"`
 	Username          string `db:"username"`
@@ -9,6 +11,13 @@ type UserAssignmentStatistics struct {
 	MergedAssignments int    `db:"merged_assignments"`
 }
 
+type StatisticsPoint struct {
+	Start       time.Time `db:"bucket_start"`
+	CreatedPRs  int       `db:"created_prs"`
+	MergedPRs   int       `db:"merged_prs"`
+	Assignments int       `db:"assignments"`
+}
+
 type PRStatistics struct {
 	TotalPRs  int `db:"total"`
 	OpenPRs   int `db:"open"`
@@ -23,3 +32,24 @@ type UserStatistics struct {
 type TeamStatistics struct {
 	TotalTeams int `db:"total"`
 }
+
+// LatencyRow is a latency summary of one group. Total marks the row over
+// all groups.
+type LatencyRow struct {
+	MedianSeconds *float64 `db:"median_seconds"`
+	P90Seconds    *float64 `db:"p90_seconds"`
+	GroupID       string   `db:"group_id"`
+	GroupName     string   `db:"group_name"`
+	Count         int      `db:"count"`
+	Total         bool     `db:"total"`
+}
+
+// AssignmentCountRow counts the assignments of one group. Total marks the
+// row over all groups.
+type AssignmentCountRow struct {
+	GroupID       string `db:"group_id"`
+	GroupName     string `db:"group_name"`
+	Assignments   int    `db:"assignments"`
+	Reassignments int    `db:"reassignments"`
+	Total         bool   `db:"total"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+type OutboxEvent struct {
+	CreatedAt time.Time     `db:"created_at"`
+	Payload   OutboxPayload `db:"payload"`
+	EventType string        `db:"event_type"`
+	PRID      string        `db:"pr_id"`
+	ID        int64         `db:"id"`
+}
+
+type OutboxPayload struct {
+	PRName        string   `json:"pull_request_name"`
+	AuthorID      string   `json:"author_id"`
+	Reviewers     []string `json:"reviewers"`
+	OldReviewerID string   `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string   `json:"new_reviewer_id,omitempty"`
+}
+
+type Subscription struct {
+	CreatedAt  time.Time `db:"created_at"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []string  `db:"event_types"`
+	ID         int64     `db:"id"`
+}
+
+type SubscriptionDelivery struct {
+	NextAttemptAt      time.Time     `db:"next_attempt_at"`
+	CreatedAt          time.Time     `db:"created_at"`
+	DeliveredAt        *time.Time    `db:"delivered_at"`
+	LastStatusCode     *int          `db:"last_status_code"`
+	LastError          *string       `db:"last_error"`
+	EventCreatedAt     time.Time     `db:"event_created_at"`
+	EventPayload       OutboxPayload `db:"event_payload"`
+	EventType          string        `db:"event_type"`
+	EventPRID          string        `db:"event_pr_id"`
+	SubscriptionURL    string        `db:"subscription_url"`
+	SubscriptionSecret string        `db:"subscription_secret"`
+	Status             string        `db:"status"`
+	ID                 int64         `db:"id"`
+	SubscriptionID     int64         `db:"subscription_id"`
+	EventID            int64         `db:"event_id"`
+	Attempts           int           `db:"attempts"`
+}
@@ -3,16 +3,40 @@ package entity
 import "time"
 
 type Team struct {
-	CreatedAt time.Time `db:"created_at"`
-	Name      string    `db:"name"`
-	Members   []Member  `db:"-"`
-	ID        int       `db:"id"`
+	CreatedAt        time.Time `db:"created_at"`
+	Name             string    `db:"name"`
+	ParentName       string    `db:"parent_name"`
+	Members          []Member  `db:"-"`
+	ReviewSLAMinutes *int      `db:"review_sla_minutes"`
+	ReviewerFallback *string   `db:"reviewer_fallback"`
+	ID               int       `db:"id"`
+	ParentID         int       `db:"parent_id"`
+}
+
+type TeamSummary struct {
+	Team
+	MemberCount       int `db:"member_count"`
+	ActiveMemberCount int `db:"active_member_count"`
+}
+
+type TeamNode struct {
+	Name             string `db:"name"`
+	ID               int    `db:"id"`
+	ParentID         int    `db:"parent_id"`
+	MemberCount      int    `db:"member_count"`
+	TotalMemberCount int    `db:"total_member_count"`
+	OpenReviews      int    `db:"open_reviews"`
+	TotalOpenReviews int    `db:"total_open_reviews"`
 }
 
 type Member struct {
-	CreatedAt time.Time `db:"created_at"`
-	UserID    string    `db:"id"`
-	Username  string    `db:"username"`
-	TeamID    int       `db:"team_id"`
-	IsActive  bool      `db:"is_active"`
+	CreatedAt time.Time        `db:"created_at"`
+	UserID    string           `db:"id"`
+	Username  string           `db:"username"`
+	Teams     []TeamMembership `db:"-"`
+	Timezone  string           `db:"timezone"`
+	TeamID    int              `db:"team_id"`
+	WorkStart int              `db:"work_start"`
+	WorkEnd   int              `db:"work_end"`
+	IsActive  bool             `db:"is_active"`
 }
@@ -3,10 +3,34 @@ package entity
 import "time"
 
 type User struct {
-	CreatedAt time.Time `db:"created_at"`
-	ID        string    `db:"id"`
-	Username  string    `db:"username"`
-	TeamName  string    `db:"-"`
-	TeamID    int       `db:"team_id"`
-	IsActive  bool      `db:"is_active"`
+	CreatedAt     time.Time        `db:"created_at"`
+	ID            string           `db:"id"`
+	Username      string           `db:"username"`
+	Email         string           `db:"email"`
+	Teams         []TeamMembership `db:"-"`
+	Timezone      string           `db:"timezone"`
+	Role          string           `db:"role"`
+	WorkStart     int              `db:"work_start"`
+	WorkEnd       int              `db:"work_end"`
+	OpenReviews   int              `db:"open_reviews"`
+	IsActive      bool             `db:"is_active"`
+	DigestEnabled bool             `db:"digest_enabled"`
+}
+
+type TeamMembership struct {
+	UserID   string `db:"user_id"`
+	TeamName string `db:"team_name"`
+	Role     string `db:"role"`
+	TeamID   int    `db:"team_id"`
+}
+
+type DigestRecipient struct {
+	SentAt        *time.Time `db:"digest_sent_at"`
+	ID            string     `db:"id"`
+	Username      string     `db:"username"`
+	Email         string     `db:"email"`
+	Timezone      string     `db:"timezone"`
+	WorkStart     int        `db:"work_start"`
+	WorkEnd       int        `db:"work_end"`
+	DigestEnabled bool       `db:"digest_enabled"`
 }
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+func (s *Storage) GetAssignmentLog(ctx context.Context, prId string) ([]*domain.AssignmentDecision, error) {
+	const op = "internal.repository.postgres.assignment.GetAssignmentLog"
+
+	builder := sq.Select(
+		"id", "pr_id", "trigger", "strategy", "seed",
+		"candidates", "exclusions", "scores", "chosen", "created_at",
+		"COALESCE(team_id, 0) AS team_id", "COALESCE(actor_id, '') AS actor_id",
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("assignment_logs").
+		Where(sq.Eq{"pr_id": prId}).
+		OrderBy("created_at", "id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var logs []*entity.AssignmentLog
+	err = pgxscan.Select(ctx, s.pgxPool, &logs, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	decisions := make([]*domain.AssignmentDecision, len(logs))
+	for i, log := range logs {
+		decisions[i] = converter.ToDomainAssignmentLogFromEntity(log)
+	}
+
+	return decisions, nil
+}
+
+func insertAssignmentLog(ctx context.Context, tx pgx.Tx, decision *domain.AssignmentDecision) error {
+	const op = "internal.repository.postgres.assignment.insertAssignmentLog"
+
+	logEntity := converter.ToEntityAssignmentLogFromDomain(decision)
+
+	builder := sq.Insert("assignment_logs").
+		PlaceholderFormat(sq.Dollar).
+		Columns("pr_id", "team_id", "actor_id", "trigger", "strategy", "seed", "candidates", "exclusions", "scores", "chosen").
+		Values(
+			logEntity.PRID,
+			nullableTeamID(logEntity.TeamID),
+			nullableString(logEntity.ActorID),
+			logEntity.Trigger,
+			logEntity.Strategy,
+			logEntity.Seed,
+			logEntity.Candidates,
+			logEntity.Exclusions,
+			logEntity.Scores,
+			logEntity.Chosen,
+		)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/requestid"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// auditSnapshots select the audited state of an entity as a JSON object.
+// Secrets and bookkeeping columns are left out. A PR's reviews are each
+// reviewer's latest decision.
+var auditSnapshots = map[domain.AuditEntity]string{
+	domain.AuditEntityPR: `
+        SELECT to_jsonb(pr) || jsonb_build_object('reviewers', COALESCE((
+            SELECT jsonb_agg(prw.user_id ORDER BY prw.user_id)
+            FROM pr_reviewers prw
+            WHERE prw.pr_id = pr.id AND prw.unassigned_at IS NULL), '[]'::jsonb),
+        'reviews', (
+            SELECT jsonb_object_agg(r.user_id, r.decision)
+            FROM (
+                SELECT DISTINCT ON (e.user_id) e.user_id, e.decision
+                FROM pr_events e
+                WHERE e.pr_id = pr.id AND e.type = 'reviewed'
+                ORDER BY e.user_id, e.created_at DESC, e.id DESC
+            ) r))
+        FROM pull_requests pr
+        WHERE pr.id = $1
+        FOR UPDATE OF pr`,
+	domain.AuditEntityUser: `
+        SELECT (to_jsonb(u) - 'digest_sent_at') || jsonb_build_object('teams', COALESCE((
+            SELECT jsonb_object_agg(t.name, tm.role)
+            FROM team_members tm
+            JOIN teams t ON t.id = tm.team_id
+            WHERE tm.user_id = u.id), '{}'::jsonb))
+        FROM users u
+        WHERE u.id = $1
+        FOR UPDATE OF u`,
+	domain.AuditEntityTeam: `
+        SELECT to_jsonb(t) || jsonb_build_object('members', COALESCE((
+            SELECT jsonb_object_agg(tm.user_id, tm.role)
+            FROM team_members tm
+            WHERE tm.team_id = t.id), '{}'::jsonb))
+        FROM teams t
+        WHERE t.id = $1::TEXT::INTEGER
+        FOR UPDATE OF t`,
+	domain.AuditEntitySubscription: `
+        SELECT to_jsonb(s) - 'secret'
+        FROM subscriptions s
+        WHERE s.id = $1::TEXT::BIGINT
+        FOR UPDATE`,
+	domain.AuditEntityToken: `
+        SELECT to_jsonb(t) - 'token_hash' - 'last_used_at'
+        FROM api_tokens t
+        WHERE t.id = $1::TEXT::BIGINT
+        FOR UPDATE`,
+}
+
+// auditChange records one mutation in audit_events. Start it before the
+// mutation and finish it after, in the same transaction.
+type auditChange struct {
+	before     map[string]any
+	action     string
+	entityType domain.AuditEntity
+	entityId   string
+}
+
+// startAudit snapshots the entity before the mutation. Entities about to be
+// created have no ID yet; set it on the change once they are inserted.
+func startAudit(
+	ctx context.Context,
+	tx pgx.Tx,
+	action string,
+	entityType domain.AuditEntity,
+	entityId string,
+) (*auditChange, error) {
+	const op = "internal.repository.postgres.audit.startAudit"
+
+	change := &auditChange{
+		action:     action,
+		entityType: entityType,
+		entityId:   entityId,
+	}
+	if entityId == "" {
+		return change, nil
+	}
+
+	before, err := auditSnapshot(ctx, tx, entityType, entityId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	change.before = before
+
+	return change, nil
+}
+
+// finish snapshots the entity after the mutation and appends an event with
+// the fields that changed. Mutations that changed nothing are not recorded.
+func (c *auditChange) finish(ctx context.Context, tx pgx.Tx) error {
+	const op = "internal.repository.postgres.audit.finish"
+
+	after, err := auditSnapshot(ctx, tx, c.entityType, c.entityId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	diff := auditDiff(c.before, after)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	builder := sq.Insert("audit_events").
+		PlaceholderFormat(sq.Dollar).
+		Columns("actor", "action", "entity_type", "entity_id", "diff", "request_id").
+		Values(contextActor(ctx), c.action, string(c.entityType), c.entityId, diffJSON, nullableString(requestid.FromContext(ctx)))
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// execAudited runs a single-statement mutation of an existing entity and
+// records it. It returns the number of affected rows; nothing is recorded
+// when no row was affected.
+func (s *Storage) execAudited(
+	ctx context.Context,
+	action string,
+	entityType domain.AuditEntity,
+	entityId string,
+	builder sq.Sqlizer,
+) (int64, error) {
+	const op = "internal.repository.postgres.audit.execAudited"
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, action, entityType, entityId)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), tx.Commit(ctx)
+}
+
+// contextActor identifies the caller of the request for the audit log and
+// PR timelines.
+func contextActor(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.Actor()
+	}
+
+	return domain.AuditActorSystem
+}
+
+// auditSnapshot returns nil for entities that do not exist.
+func auditSnapshot(
+	ctx context.Context,
+	tx pgx.Tx,
+	entityType domain.AuditEntity,
+	entityId string,
+) (map[string]any, error) {
+	var data []byte
+	err := tx.QueryRow(ctx, auditSnapshots[entityType], entityId).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]any
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func auditDiff(before, after map[string]any) map[string]entity.AuditChange {
+	diff := make(map[string]entity.AuditChange)
+	for field, value := range before {
+		if !reflect.DeepEqual(value, after[field]) {
+			diff[field] = entity.AuditChange{Before: value, After: after[field]}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok && value != nil {
+			diff[field] = entity.AuditChange{After: value}
+		}
+	}
+
+	return diff
+}
+
+func (s *Storage) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	const op = "internal.repository.postgres.audit.ListAuditEvents"
+
+	builder := sq.Select(
+		"id", "actor", "action", "entity_type", "entity_id", "diff",
+		"COALESCE(request_id, '') AS request_id", "created_at",
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("audit_events").
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset))
+
+	if filter.EntityType != "" {
+		builder = builder.Where(sq.Eq{"entity_type": string(filter.EntityType)})
+	}
+	if filter.EntityID != "" {
+		builder = builder.Where(sq.Eq{"entity_id": filter.EntityID})
+	}
+	if filter.Actor != "" {
+		builder = builder.Where(sq.Eq{"actor": filter.Actor})
+	}
+	if filter.From != nil {
+		builder = builder.Where(sq.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		builder = builder.Where(sq.Lt{"created_at": *filter.To})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var events []*entity.AuditEvent
+	err = pgxscan.Select(ctx, s.pgxPool, &events, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainAuditEventsFromEntity(events), nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ClaimDelivery records a webhook delivery and reports whether it was seen
+// for the first time.
+func (s *Storage) ClaimDelivery(ctx context.Context, provider string, deliveryId string) (bool, error) {
+	const op = "internal.repository.postgres.delivery.ClaimDelivery"
+
+	builder := sq.Insert("webhook_deliveries").
+		PlaceholderFormat(sq.Dollar).
+		Columns("provider", "delivery_id").
+		Values(provider, deliveryId).
+		Suffix("ON CONFLICT (provider, delivery_id) DO NOTHING")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseDelivery forgets a delivery so that a redelivery is processed again.
+func (s *Storage) ReleaseDelivery(ctx context.Context, provider string, deliveryId string) error {
+	const op = "internal.repository.postgres.delivery.ReleaseDelivery"
+
+	builder := sq.Delete("webhook_deliveries").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"provider": provider}).
+		Where(sq.Eq{"delivery_id": deliveryId})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
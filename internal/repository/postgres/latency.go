@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// The latency queries group by team and add a total row through GROUPING
+// SETS. PRs without a team form the group with ID 0. pr_events stores
+// TIMESTAMPTZ while the older tables store UTC in plain TIMESTAMP columns, so
+// those are read AT TIME ZONE 'UTC' before they are compared with events.
+const (
+	timeToMergeQuery = `
+        SELECT GROUPING(pr.team_id) = 1 AS total,
+               COALESCE(pr.team_id, 0)::TEXT AS group_id,
+               COALESCE(MAX(t.name), '') AS group_name,
+               COUNT(*) AS count,
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) AS median_seconds,
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) AS p90_seconds
+        FROM pull_requests pr
+        LEFT JOIN teams t ON t.id = pr.team_id
+        WHERE pr.merged_at >= $1 AND pr.merged_at < $2
+        GROUP BY GROUPING SETS ((pr.team_id), ())`
+
+	timeToFirstReviewQuery = `
+        WITH first_reviews AS (
+            SELECT pr_id, MIN(created_at) AS reviewed_at
+            FROM pr_events
+            WHERE type = 'reviewed'
+            GROUP BY pr_id
+        )
+        SELECT GROUPING(pr.team_id) = 1 AS total,
+               COALESCE(pr.team_id, 0)::TEXT AS group_id,
+               COALESCE(MAX(t.name), '') AS group_name,
+               COUNT(*) AS count,
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.reviewed_at - (pr.created_at AT TIME ZONE 'UTC'))) AS median_seconds,
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.reviewed_at - (pr.created_at AT TIME ZONE 'UTC'))) AS p90_seconds
+        FROM first_reviews fr
+        JOIN pull_requests pr ON pr.id = fr.pr_id
+        LEFT JOIN teams t ON t.id = pr.team_id
+        WHERE fr.reviewed_at >= $1 AND fr.reviewed_at < $2
+        GROUP BY GROUPING SETS ((pr.team_id), ())`
+
+	// reviewerFirstReviewQuery measures from the assignment the review was
+	// given under, the latest one before the review.
+	reviewerFirstReviewQuery = `
+        WITH first_reviews AS (
+            SELECT pr_id, user_id, MIN(created_at) AS reviewed_at
+            FROM pr_events
+            WHERE type = 'reviewed'
+            GROUP BY pr_id, user_id
+        ),
+        waits AS (
+            SELECT fr.user_id, fr.reviewed_at - (
+                SELECT MAX(prw.assigned_at AT TIME ZONE 'UTC')
+                FROM pr_reviewers prw
+                WHERE prw.pr_id = fr.pr_id AND prw.user_id = fr.user_id
+                  AND prw.assigned_at AT TIME ZONE 'UTC' <= fr.reviewed_at
+            ) AS wait
+            FROM first_reviews fr
+            WHERE fr.reviewed_at >= $1 AND fr.reviewed_at < $2
+        )
+        SELECT FALSE AS total,
+               w.user_id AS group_id,
+               COALESCE(MAX(u.username), '') AS group_name,
+               COUNT(w.wait) AS count,
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM w.wait)) AS median_seconds,
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM w.wait)) AS p90_seconds
+        FROM waits w
+        LEFT JOIN users u ON u.id = w.user_id
+        GROUP BY w.user_id`
+
+	teamAssignmentsQuery = `
+        SELECT GROUPING(pr.team_id) = 1 AS total,
+               COALESCE(pr.team_id, 0)::TEXT AS group_id,
+               COALESCE(MAX(t.name), '') AS group_name,
+               COUNT(*) AS assignments,
+               COUNT(*) FILTER (WHERE prw.unassign_reason = ANY($3)) AS reassignments
+        FROM pr_reviewers prw
+        JOIN pull_requests pr ON pr.id = prw.pr_id
+        LEFT JOIN teams t ON t.id = pr.team_id
+        WHERE prw.assigned_at >= $1 AND prw.assigned_at < $2
+        GROUP BY GROUPING SETS ((pr.team_id), ())`
+
+	reviewerAssignmentsQuery = `
+        SELECT FALSE AS total,
+               prw.user_id AS group_id,
+               COALESCE(MAX(u.username), '') AS group_name,
+               COUNT(*) AS assignments,
+               COUNT(*) FILTER (WHERE prw.unassign_reason = ANY($3)) AS reassignments
+        FROM pr_reviewers prw
+        LEFT JOIN users u ON u.id = prw.user_id
+        WHERE prw.assigned_at >= $1 AND prw.assigned_at < $2
+        GROUP BY prw.user_id`
+)
+
+// reassignReasons are the unassign reasons that count as reassignments.
+var reassignReasons = []string{domain.UnassignReasonReassigned, domain.UnassignReasonMovedTeam}
+
+// GetLatencyStatistics measures time-to-merge, time-to-first-review and the
+// reassignment rate over the period, overall, per team and per reviewer.
+func (s *Storage) GetLatencyStatistics(ctx context.Context, filter domain.LatencyFilter) (*domain.LatencyStatistics, error) {
+	const op = "internal.repository.postgres.latency.GetLatencyStatistics"
+
+	from, to := filter.From.UTC(), filter.To.UTC()
+
+	var mergeRows, reviewRows, reviewerRows []entity.LatencyRow
+	for _, q := range []struct {
+		rows  *[]entity.LatencyRow
+		query string
+	}{
+		{&mergeRows, timeToMergeQuery},
+		{&reviewRows, timeToFirstReviewQuery},
+		{&reviewerRows, reviewerFirstReviewQuery},
+	} {
+		err := pgxscan.Select(ctx, s.pgxPool, q.rows, q.query, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var teamAssignments, reviewerAssignments []entity.AssignmentCountRow
+	for _, q := range []struct {
+		rows  *[]entity.AssignmentCountRow
+		query string
+	}{
+		{&teamAssignments, teamAssignmentsQuery},
+		{&reviewerAssignments, reviewerAssignmentsQuery},
+	} {
+		err := pgxscan.Select(ctx, s.pgxPool, q.rows, q.query, from, to, reassignReasons)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	statistics := &domain.LatencyStatistics{
+		From: filter.From,
+		To:   filter.To,
+	}
+
+	teams := make(map[string]*domain.TeamLatency)
+	team := func(id string, name string) *domain.TeamLatency {
+		if teams[id] == nil {
+			teamId, _ := strconv.Atoi(id)
+			teams[id] = &domain.TeamLatency{TeamID: teamId, TeamName: name}
+		}
+
+		return teams[id]
+	}
+	for _, row := range mergeRows {
+		if row.Total {
+			statistics.Overall.TimeToMerge = converter.ToDomainLatencyFromEntity(row)
+			continue
+		}
+		team(row.GroupID, row.GroupName).TimeToMerge = converter.ToDomainLatencyFromEntity(row)
+	}
+	for _, row := range reviewRows {
+		if row.Total {
+			statistics.Overall.TimeToFirstReview = converter.ToDomainLatencyFromEntity(row)
+			continue
+		}
+		team(row.GroupID, row.GroupName).TimeToFirstReview = converter.ToDomainLatencyFromEntity(row)
+	}
+	for _, row := range teamAssignments {
+		metrics := &statistics.Overall
+		if !row.Total {
+			metrics = &team(row.GroupID, row.GroupName).LatencyMetrics
+		}
+		metrics.Assignments = row.Assignments
+		metrics.Reassignments = row.Reassignments
+	}
+
+	reviewers := make(map[string]*domain.ReviewerLatency)
+	reviewer := func(id string, name string) *domain.ReviewerLatency {
+		if reviewers[id] == nil {
+			reviewers[id] = &domain.ReviewerLatency{UserID: id, Username: name}
+		}
+
+		return reviewers[id]
+	}
+	for _, row := range reviewerRows {
+		reviewer(row.GroupID, row.GroupName).TimeToFirstReview = converter.ToDomainLatencyFromEntity(row)
+	}
+	for _, row := range reviewerAssignments {
+		latency := reviewer(row.GroupID, row.GroupName)
+		latency.Assignments = row.Assignments
+		latency.Reassignments = row.Reassignments
+	}
+
+	for _, latency := range teams {
+		statistics.Teams = append(statistics.Teams, *latency)
+	}
+	slices.SortFunc(statistics.Teams, func(a, b domain.TeamLatency) int {
+		return cmp.Compare(a.TeamName, b.TeamName)
+	})
+	for _, latency := range reviewers {
+		statistics.Reviewers = append(statistics.Reviewers, *latency)
+	}
+	slices.SortFunc(statistics.Reviewers, func(a, b domain.ReviewerLatency) int {
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+
+	return statistics, nil
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// EnqueueNotifications queues notifications to be sent by the notification
+// worker.
+func (s *Storage) EnqueueNotifications(ctx context.Context, notifications []domain.Notification) error {
+	const op = "internal.repository.postgres.notification.EnqueueNotifications"
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	builder := notificationInsert()
+	for _, notification := range notifications {
+		builder = builder.Values(notificationValues(notification)...)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EnqueueOverdueNotification queues the notification about an overdue review
+// and marks the review as reported, so that it is notified once per
+// assignment. It does nothing if the review has been reported already.
+func (s *Storage) EnqueueOverdueNotification(ctx context.Context, notification domain.Notification) error {
+	const op = "internal.repository.postgres.notification.EnqueueOverdueNotification"
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	builder := sq.Update("pr_reviewers").
+		PlaceholderFormat(sq.Dollar).
+		Set("overdue_notified_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"pr_id": notification.PRID}).
+		Where(sq.Eq{"user_id": notification.UserID}).
+		Where(sq.Eq{"unassigned_at": nil}).
+		Where(sq.Eq{"overdue_notified_at": nil})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	err = insertNotification(ctx, tx, notification)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetUnreportedReviews returns the reviews of open PRs by active users that
+// have not been reported overdue since they were assigned.
+func (s *Storage) GetUnreportedReviews(ctx context.Context) ([]*domain.OpenReview, error) {
+	const op = "internal.repository.postgres.notification.GetUnreportedReviews"
+
+	builder := sq.Select(
+		"pr.id AS pr_id", "pr.name AS pr_name", "COALESCE(pr.team_id, 0) AS team_id",
+		"COALESCE(t.name, '') AS team_name", "prw.user_id", "prw.assigned_at",
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("pr_reviewers prw").
+		Join("pull_requests pr ON pr.id = prw.pr_id").
+		Join("users u ON u.id = prw.user_id").
+		LeftJoin("teams t ON t.id = pr.team_id").
+		Where(sq.Eq{"pr.status": "OPEN"}).
+		Where(sq.Eq{"u.is_active": true}).
+		Where(sq.Eq{"prw.unassigned_at": nil}).
+		Where(sq.Eq{"prw.overdue_notified_at": nil}).
+		Where(sq.NotEq{"prw.assigned_at": nil}).
+		OrderBy("prw.assigned_at")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var reviews []*entity.OpenReview
+	err = pgxscan.Select(ctx, s.pgxPool, &reviews, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainOpenReviewsFromEntity(reviews), nil
+}
+
+// ClaimNotifications returns up to limit queued notifications that are due
+// and postpones them by lease, so that concurrent workers skip them while
+// they are being sent.
+func (s *Storage) ClaimNotifications(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*domain.QueuedNotification, error) {
+	const op = "internal.repository.postgres.notification.ClaimNotifications"
+
+	query := `
+		UPDATE notification_queue
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notification_queue
+			WHERE failed_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, pr_id, pr_name, team_name, user_id, replaced_user_id, wait_seconds,
+			attempts, last_error, next_attempt_at, failed_at`
+
+	var notifications []*entity.Notification
+	err := pgxscan.Select(ctx, s.pgxPool, &notifications, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainNotificationsFromEntity(notifications), nil
+}
+
+// UpdateNotification records a failed attempt.
+func (s *Storage) UpdateNotification(ctx context.Context, id int64, attempt domain.NotificationAttempt) error {
+	const op = "internal.repository.postgres.notification.UpdateNotification"
+
+	builder := sq.Update("notification_queue").
+		PlaceholderFormat(sq.Dollar).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", attempt.Error).
+		Set("next_attempt_at", retryAt(attempt.RetryIn)).
+		Set("failed_at", failedAt(attempt.GiveUp)).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteNotification removes a notification that has been sent.
+func (s *Storage) DeleteNotification(ctx context.Context, id int64) error {
+	const op = "internal.repository.postgres.notification.DeleteNotification"
+
+	builder := sq.Delete("notification_queue").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func insertNotification(ctx context.Context, tx pgx.Tx, notification domain.Notification) error {
+	const op = "internal.repository.postgres.notification.insertNotification"
+
+	query, args, err := notificationInsert().
+		Values(notificationValues(notification)...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func notificationInsert() sq.InsertBuilder {
+	return sq.Insert("notification_queue").
+		PlaceholderFormat(sq.Dollar).
+		Columns("kind", "pr_id", "pr_name", "team_name", "user_id", "replaced_user_id", "wait_seconds")
+}
+
+func notificationValues(notification domain.Notification) []any {
+	return []any{
+		string(notification.Kind), notification.PRID, notification.PRName, notification.TeamName,
+		notification.UserID, notification.ReplacedUserID, int64(notification.Wait.Seconds()),
+	}
+}
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// insertOutboxEvent records an event about the PR within the transaction
+// that changes it, so that the event is published if and only if the change
+// is committed. The payload reflects the PR as the transaction sees it.
+func insertOutboxEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventType domain.EventType,
+	prId string,
+	oldReviewerId string,
+	newReviewerId string,
+) error {
+	const op = "internal.repository.postgres.outbox.insertOutboxEvent"
+
+	builder := sq.Select("id", "name", "author_id").
+		PlaceholderFormat(sq.Dollar).
+		From("pull_requests").
+		Where(sq.Eq{"id": prId})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var pr entity.PR
+	err = pgxscan.Get(ctx, tx, &pr, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewersBuilder := sq.Select("user_id").
+		PlaceholderFormat(sq.Dollar).
+		From("pr_reviewers").
+		Where(sq.Eq{"pr_id": prId}).
+		Where(sq.Eq{"unassigned_at": nil}).
+		OrderBy("assigned_at", "user_id")
+	query, args, err = reviewersBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var reviewers []string
+	err = pgxscan.Select(ctx, tx, &reviewers, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload := converter.ToEntityOutboxPayloadFromDomain(&domain.OutboxEvent{
+		PRName:        pr.Name,
+		AuthorID:      pr.AuthorID,
+		OldReviewerID: oldReviewerId,
+		NewReviewerID: newReviewerId,
+		Reviewers:     reviewers,
+	})
+
+	insertBuilder := sq.Insert("outbox_events").
+		PlaceholderFormat(sq.Dollar).
+		Columns("event_type", "pr_id", "payload").
+		Values(string(eventType), prId, payload)
+	query, args, err = insertBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FanOutOutboxEvents creates a delivery for every subscription of up to
+// limit undispatched events and marks the events dispatched. Events nobody
+// subscribes to are marked dispatched as well.
+func (s *Storage) FanOutOutboxEvents(ctx context.Context, limit int) (int, error) {
+	const op = "internal.repository.postgres.outbox.FanOutOutboxEvents"
+
+	query := `
+		WITH events AS (
+			SELECT id, event_type FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO subscription_deliveries (subscription_id, event_id)
+			SELECT s.id, e.id
+			FROM events e
+			JOIN subscriptions s ON e.event_type = ANY(s.event_types)
+			ON CONFLICT (subscription_id, event_id) DO NOTHING
+		)
+		UPDATE outbox_events SET dispatched_at = NOW()
+		WHERE id IN (SELECT id FROM events)`
+
+	result, err := s.pgxPool.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimSubscriptionDeliveries returns up to limit pending deliveries that
+// are due and postpones them by lease, so that concurrent workers skip them
+// while they are being sent.
+func (s *Storage) ClaimSubscriptionDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*domain.SubscriptionDelivery, error) {
+	const op = "internal.repository.postgres.outbox.ClaimSubscriptionDeliveries"
+
+	query := `
+		UPDATE subscription_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM subscriptions s, outbox_events e
+		WHERE s.id = d.subscription_id AND e.id = d.event_id
+		  AND d.id IN (
+			SELECT id FROM subscription_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING ` + deliveryColumns
+
+	var deliveries []*entity.SubscriptionDelivery
+	err := pgxscan.Select(ctx, s.pgxPool, &deliveries, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainSubscriptionDeliveriesFromEntity(deliveries), nil
+}
+
+// CompleteSubscriptionDelivery marks a delivery as delivered.
+func (s *Storage) CompleteSubscriptionDelivery(ctx context.Context, id int64, statusCode int) error {
+	const op = "internal.repository.postgres.outbox.CompleteSubscriptionDelivery"
+
+	builder := sq.Update("subscription_deliveries").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", string(domain.DeliveryStatusDelivered)).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_status_code", statusCode).
+		Set("last_error", nil).
+		Set("delivered_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateSubscriptionDelivery records a failed attempt.
+func (s *Storage) UpdateSubscriptionDelivery(ctx context.Context, id int64, attempt domain.DeliveryAttempt) error {
+	const op = "internal.repository.postgres.outbox.UpdateSubscriptionDelivery"
+
+	status := domain.DeliveryStatusPending
+	if attempt.GiveUp {
+		status = domain.DeliveryStatusFailed
+	}
+
+	var statusCode any
+	if attempt.StatusCode != 0 {
+		statusCode = attempt.StatusCode
+	}
+
+	builder := sq.Update("subscription_deliveries").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", string(status)).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_status_code", statusCode).
+		Set("last_error", attempt.Error).
+		Set("next_attempt_at", retryAt(attempt.RetryIn)).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const deliveryColumns = `d.id, d.subscription_id, d.event_id, d.status, d.attempts,
+			d.last_status_code, d.last_error, d.next_attempt_at, d.delivered_at, d.created_at,
+			s.url AS subscription_url, s.secret AS subscription_secret,
+			e.event_type, e.pr_id AS event_pr_id, e.payload AS event_payload,
+			e.created_at AS event_created_at`
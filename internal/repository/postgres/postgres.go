@@ -12,6 +12,12 @@ type Storage struct {
 
 func New(ctx context.Context, pgConfig string) (*Storage, error) {
 	pgCfg, err := pgxpool.ParseConfig(pgConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Plain TIMESTAMP columns default to the session's local time; pin it to
+	// UTC so they hold UTC whatever the server's time zone is.
+	pgCfg.ConnConfig.RuntimeParams["timezone"] = "UTC"
 
 	dbc, err := pgxpool.NewWithConfig(ctx, pgCfg)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -15,7 +16,7 @@ import (
 	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
-func (s *Storage) Create(ctx context.Context, pr domain.PR) (*domain.PR, error) {
+func (s *Storage) Create(ctx context.Context, pr domain.PR, decisions []*domain.AssignmentDecision) (*domain.PR, error) {
 	const op = "internal.repository.postgres.postgres.Create"
 
 	prEntity := entity.PR{
@@ -24,6 +25,12 @@ func (s *Storage) Create(ctx context.Context, pr domain.PR) (*domain.PR, error)
 		AuthorID:  pr.AuthorID,
 		Status:    converter.PRStatusToString(pr.Status),
 		Reviewers: pr.Reviewers,
+		TeamID:    pr.TeamID,
+	}
+	if pr.Source != nil {
+		prEntity.SourceProvider = &pr.Source.Provider
+		prEntity.SourceRepository = &pr.Source.Repository
+		prEntity.SourceNumber = &pr.Source.Number
 	}
 
 	tx, err := s.pgxPool.Begin(ctx)
@@ -33,10 +40,18 @@ func (s *Storage) Create(ctx context.Context, pr domain.PR) (*domain.PR, error)
 
 	defer tx.Rollback(ctx)
 
+	change, err := startAudit(ctx, tx, domain.AuditActionPRCreate, domain.AuditEntityPR, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	builder := sq.Insert("pull_requests").
 		PlaceholderFormat(sq.Dollar).
-		Columns("id", "name", "author_id", "status").
-		Values(prEntity.ID, prEntity.Name, prEntity.AuthorID, prEntity.Status).
+		Columns("id", "name", "author_id", "status", "team_id", "source_provider", "source_repository", "source_number").
+		Values(
+			prEntity.ID, prEntity.Name, prEntity.AuthorID, prEntity.Status, nullableTeamID(pr.TeamID),
+			prEntity.SourceProvider, prEntity.SourceRepository, prEntity.SourceNumber,
+		).
 		Suffix("RETURNING created_at")
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -73,6 +88,39 @@ func (s *Storage) Create(ctx context.Context, pr domain.PR) (*domain.PR, error)
 		}
 	}
 
+	for _, decision := range decisions {
+		err = insertAssignmentLog(ctx, tx, decision)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	events := []domain.PREvent{{Type: domain.PREventCreated}}
+	for _, reviewer := range pr.Reviewers {
+		events = append(events, domain.PREvent{
+			Type:   domain.PREventReviewerAssigned,
+			UserID: reviewer,
+			Reason: domain.AssignmentTriggerCreate,
+		})
+	}
+	err = insertPREvents(ctx, tx, pr.ID, events...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change.entityId = pr.ID
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(pr.Reviewers) > 0 {
+		err = insertOutboxEvent(ctx, tx, domain.EventReviewersAssigned, pr.ID, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -84,7 +132,11 @@ func (s *Storage) Create(ctx context.Context, pr domain.PR) (*domain.PR, error)
 func (s *Storage) Get(ctx context.Context, prId string) (*domain.PR, error) {
 	const op = "internal.repository.postgres.postgres.Get"
 
-	builder := sq.Select("id", "name", "author_id", "status", "created_at", "merged_at").
+	builder := sq.Select(
+		"id", "name", "author_id", "status", "COALESCE(team_id, 0) AS team_id",
+		"created_at", "merged_at", "closed_at", "COALESCE(merged_by, '') AS merged_by",
+		"source_provider", "source_repository", "source_number",
+	).
 		PlaceholderFormat(sq.Dollar).
 		From("pull_requests").
 		Where(sq.Eq{"id": prId})
@@ -105,7 +157,8 @@ func (s *Storage) Get(ctx context.Context, prId string) (*domain.PR, error) {
 	reviewersBuilder := sq.Select("user_id").
 		PlaceholderFormat(sq.Dollar).
 		From("pr_reviewers").
-		Where(sq.Eq{"pr_id": prId})
+		Where(sq.Eq{"pr_id": prId}).
+		Where(sq.Eq{"unassigned_at": nil})
 	query, args, err = reviewersBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -121,20 +174,35 @@ func (s *Storage) Get(ctx context.Context, prId string) (*domain.PR, error) {
 	return converter.ToDomainPRFromEntity(&pr), nil
 }
 
-func (s *Storage) Merge(ctx context.Context, prId string) (*domain.PR, error) {
+// Merge marks the PR as merged by the user, or by nobody in particular when
+// mergedBy is empty.
+func (s *Storage) Merge(ctx context.Context, prId string, mergedBy string) (*domain.PR, error) {
 	const op = "internal.repository.postgres.postgres.Merge"
 
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionPRMerge, domain.AuditEntityPR, prId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	builder := sq.Update("pull_requests").
 		PlaceholderFormat(sq.Dollar).
 		Set("status", "MERGED").
 		Set("merged_at", sq.Expr("NOW()")).
+		Set("merged_by", nullableString(mergedBy)).
 		Where(sq.Eq{"id": prId})
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	result, err := s.pgxPool.Exec(ctx, query, args...)
+	result, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -143,6 +211,48 @@ func (s *Storage) Merge(ctx context.Context, prId string) (*domain.PR, error) {
 		return nil, fmt.Errorf("%s: %w", op, repository.ErrPRNotFound)
 	}
 
+	err = insertPREvents(ctx, tx, prId, domain.PREvent{Type: domain.PREventMerged})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.EventPRMerged, prId, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr, err := s.Get(ctx, prId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func (s *Storage) ClosePR(ctx context.Context, prId string) (*domain.PR, error) {
+	const op = "internal.repository.postgres.postgres.ClosePR"
+
+	builder := sq.Update("pull_requests").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", "CLOSED").
+		Set("closed_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": prId})
+
+	err := s.setPRStatus(ctx, prId, builder, domain.AuditActionPRClose, domain.PREventClosed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	pr, err := s.Get(ctx, prId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -151,13 +261,84 @@ func (s *Storage) Merge(ctx context.Context, prId string) (*domain.PR, error) {
 	return pr, nil
 }
 
+func (s *Storage) ReopenPR(ctx context.Context, prId string) (*domain.PR, error) {
+	const op = "internal.repository.postgres.postgres.ReopenPR"
+
+	builder := sq.Update("pull_requests").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", "OPEN").
+		Set("closed_at", nil).
+		Where(sq.Eq{"id": prId})
+
+	err := s.setPRStatus(ctx, prId, builder, domain.AuditActionPRReopen, domain.PREventReopened)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr, err := s.Get(ctx, prId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+// setPRStatus applies the status update and records it in the audit log and
+// the PR's timeline.
+func (s *Storage) setPRStatus(
+	ctx context.Context,
+	prId string,
+	builder sq.UpdateBuilder,
+	action string,
+	eventType domain.PREventType,
+) error {
+	const op = "internal.repository.postgres.postgres.setPRStatus"
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, action, domain.AuditEntityPR, prId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrPRNotFound)
+	}
+
+	err = insertPREvents(ctx, tx, prId, domain.PREvent{Type: eventType})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (s *Storage) GetPullRequestsIdsByReviewer(ctx context.Context, reviewerId string) ([]string, error) {
 	const op = "internal.repository.postgres.postgres.GetPullRequestsIdsByReviewer"
 
 	builder := sq.Select("pr_id").
 		PlaceholderFormat(sq.Dollar).
 		From("pr_reviewers").
-		Where(sq.Eq{"user_id": reviewerId})
+		Where(sq.Eq{"user_id": reviewerId}).
+		Where(sq.Eq{"unassigned_at": nil})
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -172,21 +353,25 @@ func (s *Storage) GetPullRequestsIdsByReviewer(ctx context.Context, reviewerId s
 	return pullRequestsIds, nil
 }
 
-func (s *Storage) GetPRStatistics(ctx context.Context) (*domain.PRStatistics, error) {
+// GetPRStatistics counts the PRs created within the filter's period.
+func (s *Storage) GetPRStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.PRStatistics, error) {
 	const op = "internal.repository.postgres.postgres.GetPRStatistics"
 
 	builder := sq.Select(
 		"COUNT(*) as total",
 		"COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as open",
 		"COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged",
-	).From("pull_requests")
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("pull_requests").
+		Where(statisticsScope(filter, "team_id", "created_at"))
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var pullRequestsStats entity.PRStatistics
-	err = pgxscan.Select(ctx, s.pgxPool, &pullRequestsStats, query, args...)
+	err = pgxscan.Get(ctx, s.pgxPool, &pullRequestsStats, query, args...)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, repository.ErrStatisticsNotFound
 	}
@@ -196,3 +381,54 @@ func (s *Storage) GetPRStatistics(ctx context.Context) (*domain.PRStatistics, er
 
 	return converter.ToDomainPRStatisticsFromEntity(&pullRequestsStats), nil
 }
+
+// GetPairingCounts counts how often each user reviewed the author's PRs or had
+// their own PRs reviewed by the author since the given moment. Only current
+// reviewers count: a reviewer reassigned away never reviewed the PR, so the
+// pairing did not happen.
+func (s *Storage) GetPairingCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error) {
+	const op = "internal.repository.postgres.postgres.GetPairingCounts"
+
+	query := `
+		SELECT partner_id, COUNT(*) AS pairings FROM (
+			SELECT prw.user_id AS partner_id
+			FROM pr_reviewers prw
+			JOIN pull_requests pr ON pr.id = prw.pr_id
+			WHERE pr.author_id = $1 AND prw.unassigned_at IS NULL AND prw.assigned_at >= $2
+			UNION ALL
+			SELECT pr.author_id AS partner_id
+			FROM pr_reviewers prw
+			JOIN pull_requests pr ON pr.id = prw.pr_id
+			WHERE prw.user_id = $1 AND prw.unassigned_at IS NULL AND prw.assigned_at >= $2
+		) pairs
+		GROUP BY partner_id`
+
+	var rows []entity.Pairing
+	err := pgxscan.Select(ctx, s.pgxPool, &rows, query, authorId, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pairings := make(map[string]int, len(rows))
+	for _, row := range rows {
+		pairings[row.PartnerID] = row.Pairings
+	}
+
+	return pairings, nil
+}
+
+func nullableTeamID(teamId int) any {
+	if teamId == 0 {
+		return nil
+	}
+
+	return teamId
+}
+
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// insertPREvents appends the events to the PR's timeline, attributed to the
+// caller of the request.
+func insertPREvents(ctx context.Context, tx pgx.Tx, prId string, events ...domain.PREvent) error {
+	const op = "internal.repository.postgres.pr_event.insertPREvents"
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	actor := contextActor(ctx)
+	builder := sq.Insert("pr_events").
+		PlaceholderFormat(sq.Dollar).
+		Columns("pr_id", "type", "actor", "user_id", "reason", "decision")
+	for _, event := range events {
+		builder = builder.Values(
+			prId, string(event.Type), actor, nullableString(event.UserID),
+			nullableString(event.Reason), nullableString(string(event.Decision)),
+		)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddReview records the reviewer's decision on the PR.
+func (s *Storage) AddReview(ctx context.Context, prId string, reviewerId string, decision domain.ReviewDecision) error {
+	const op = "internal.repository.postgres.pr_event.AddReview"
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionPRReview, domain.AuditEntityPR, prId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertPREvents(ctx, tx, prId, domain.PREvent{
+		Type:     domain.PREventReviewed,
+		UserID:   reviewerId,
+		Decision: decision,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetPRTimeline returns the PR's events, oldest first.
+func (s *Storage) GetPRTimeline(ctx context.Context, prId string) ([]*domain.PREvent, error) {
+	const op = "internal.repository.postgres.pr_event.GetPRTimeline"
+
+	builder := sq.Select(
+		"id", "type", "actor", "COALESCE(user_id, '') AS user_id", "COALESCE(reason, '') AS reason",
+		"COALESCE(decision, '') AS decision", "created_at",
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("pr_events").
+		Where(sq.Eq{"pr_id": prId}).
+		OrderBy("created_at", "id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var events []*entity.PREvent
+	err = pgxscan.Select(ctx, s.pgxPool, &events, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainPREventsFromEntity(events), nil
+}
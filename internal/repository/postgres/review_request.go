@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// EnqueueReviewRequest queues a review request after its first attempt
+// failed. Requests still queued for the PR are superseded: they are folded
+// into the new one and removed, so a PR has at most one pending request.
+func (s *Storage) EnqueueReviewRequest(
+	ctx context.Context,
+	request domain.ReviewRequest,
+	attempt domain.ReviewRequestAttempt,
+) error {
+	const op = "internal.repository.postgres.review_request.EnqueueReviewRequest"
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	supersedeBuilder := sq.Delete("review_request_queue").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"pr_id": request.PRID}).
+		Where(sq.Eq{"failed_at": nil}).
+		Suffix("RETURNING id, pr_id, reviewers, removed")
+	query, args, err := supersedeBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var superseded []*entity.ReviewRequest
+	err = pgxscan.Select(ctx, tx, &superseded, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Later requests win, so fold from the newest queued one back.
+	slices.SortFunc(superseded, func(a, b *entity.ReviewRequest) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+	for _, older := range superseded {
+		request = request.Coalesce(domain.ReviewRequest{Reviewers: older.Reviewers, Removed: older.Removed})
+	}
+
+	builder := sq.Insert("review_request_queue").
+		PlaceholderFormat(sq.Dollar).
+		Columns("pr_id", "reviewers", "removed", "attempts", "last_error", "next_attempt_at", "failed_at").
+		Values(
+			request.PRID, nonNilStrings(request.Reviewers), nonNilStrings(request.Removed), 1, attempt.Error,
+			retryAt(attempt.RetryIn), failedAt(attempt.GiveUp),
+		)
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ClaimReviewRequests returns up to limit queued requests that are due and
+// postpones them by lease, so that concurrent workers skip them while they
+// are being sent.
+func (s *Storage) ClaimReviewRequests(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*domain.QueuedReviewRequest, error) {
+	const op = "internal.repository.postgres.review_request.ClaimReviewRequests"
+
+	query := `
+		UPDATE review_request_queue q
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM pull_requests pr
+		WHERE pr.id = q.pr_id
+		  AND q.id IN (
+			SELECT id FROM review_request_queue
+			WHERE failed_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING q.id, q.pr_id, q.reviewers, q.removed, q.attempts, q.last_error,
+			q.next_attempt_at, q.failed_at,
+			COALESCE(pr.source_provider, '') AS source_provider,
+			COALESCE(pr.source_repository, '') AS source_repository,
+			COALESCE(pr.source_number, 0) AS source_number`
+
+	var requests []*entity.ReviewRequest
+	err := pgxscan.Select(ctx, s.pgxPool, &requests, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainReviewRequestsFromEntity(requests), nil
+}
+
+// UpdateReviewRequest records another failed attempt.
+func (s *Storage) UpdateReviewRequest(ctx context.Context, id int64, attempt domain.ReviewRequestAttempt) error {
+	const op = "internal.repository.postgres.review_request.UpdateReviewRequest"
+
+	builder := sq.Update("review_request_queue").
+		PlaceholderFormat(sq.Dollar).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", attempt.Error).
+		Set("next_attempt_at", retryAt(attempt.RetryIn)).
+		Set("failed_at", failedAt(attempt.GiveUp)).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteReviewRequest removes a request that has been sent.
+func (s *Storage) DeleteReviewRequest(ctx context.Context, id int64) error {
+	const op = "internal.repository.postgres.review_request.DeleteReviewRequest"
+
+	builder := sq.Delete("review_request_queue").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// retryAt is computed by the database so that it compares with NOW()
+// regardless of the client's time zone.
+func retryAt(retryIn time.Duration) sq.Sqlizer {
+	return sq.Expr("NOW() + make_interval(secs => ?)", retryIn.Seconds())
+}
+
+func failedAt(giveUp bool) any {
+	if !giveUp {
+		return nil
+	}
+
+	return sq.Expr("NOW()")
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
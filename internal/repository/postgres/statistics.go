@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// statisticsSeriesQuery counts the PRs created and merged and the reviewers
+// assigned in each bucket, clipped to the period. A zero team ID counts every
+// team.
+const statisticsSeriesQuery = `
+    WITH buckets AS (
+        SELECT bucket_start, bucket_start + ('1 ' || $3::TEXT)::INTERVAL AS bucket_end
+        FROM generate_series(
+            date_trunc($3::TEXT, $1::TIMESTAMP), $2::TIMESTAMP - INTERVAL '1 microsecond', ('1 ' || $3::TEXT)::INTERVAL
+        ) AS bucket_start
+    )
+    SELECT b.bucket_start,
+           (SELECT COUNT(*)
+            FROM pull_requests pr
+            WHERE pr.created_at >= GREATEST(b.bucket_start, $1::TIMESTAMP)
+              AND pr.created_at < LEAST(b.bucket_end, $2::TIMESTAMP)
+              AND ($4::INTEGER = 0 OR pr.team_id = $4::INTEGER)) AS created_prs,
+           (SELECT COUNT(*)
+            FROM pull_requests pr
+            WHERE pr.merged_at >= GREATEST(b.bucket_start, $1::TIMESTAMP)
+              AND pr.merged_at < LEAST(b.bucket_end, $2::TIMESTAMP)
+              AND ($4::INTEGER = 0 OR pr.team_id = $4::INTEGER)) AS merged_prs,
+           (SELECT COUNT(*)
+            FROM pr_reviewers prw
+            JOIN pull_requests pr ON pr.id = prw.pr_id
+            WHERE prw.assigned_at >= GREATEST(b.bucket_start, $1::TIMESTAMP)
+              AND prw.assigned_at < LEAST(b.bucket_end, $2::TIMESTAMP)
+              AND ($4::INTEGER = 0 OR pr.team_id = $4::INTEGER)) AS assignments
+    FROM buckets b
+    ORDER BY b.bucket_start`
+
+// GetStatisticsSeries returns one point per bucket of the filter's period,
+// which must be bounded.
+func (s *Storage) GetStatisticsSeries(ctx context.Context, filter domain.StatisticsFilter) ([]domain.StatisticsPoint, error) {
+	const op = "internal.repository.postgres.statistics.GetStatisticsSeries"
+
+	var points []entity.StatisticsPoint
+	err := pgxscan.Select(ctx, s.pgxPool, &points, statisticsSeriesQuery,
+		filter.From.UTC(), filter.To.UTC(), string(filter.Bucket), filter.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainStatisticsPointsFromEntity(points), nil
+}
+
+// statisticsScope limits rows to the filter's team and period.
+func statisticsScope(filter domain.StatisticsFilter, teamColumn string, timeColumn string) sq.And {
+	scope := sq.And{}
+	if filter.TeamID != 0 {
+		scope = append(scope, sq.Eq{teamColumn: filter.TeamID})
+	}
+	if filter.From != nil {
+		scope = append(scope, sq.GtOrEq{timeColumn: filter.From.UTC()})
+	}
+	if filter.To != nil {
+		scope = append(scope, sq.Lt{timeColumn: filter.To.UTC()})
+	}
+
+	return scope
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+func (s *Storage) CreateSubscription(ctx context.Context, subscription domain.Subscription) (*domain.Subscription, error) {
+	const op = "internal.repository.postgres.subscription.CreateSubscription"
+
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	builder := sq.Insert("subscriptions").
+		PlaceholderFormat(sq.Dollar).
+		Columns("url", "secret", "event_types").
+		Values(subscription.URL, subscription.Secret, eventTypes).
+		Suffix("RETURNING id, url, secret, event_types, created_at")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionSubscriptionCreate, domain.AuditEntitySubscription, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var subscriptionEntity entity.Subscription
+	err = pgxscan.Get(ctx, tx, &subscriptionEntity, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change.entityId = strconv.FormatInt(subscriptionEntity.ID, 10)
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainSubscriptionFromEntity(&subscriptionEntity), nil
+}
+
+func (s *Storage) GetSubscription(ctx context.Context, id int64) (*domain.Subscription, error) {
+	const op = "internal.repository.postgres.subscription.GetSubscription"
+
+	builder := sq.Select("id", "url", "secret", "event_types", "created_at").
+		PlaceholderFormat(sq.Dollar).
+		From("subscriptions").
+		Where(sq.Eq{"id": id})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var subscriptionEntity entity.Subscription
+	err = pgxscan.Get(ctx, s.pgxPool, &subscriptionEntity, query, args...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrSubscriptionNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainSubscriptionFromEntity(&subscriptionEntity), nil
+}
+
+func (s *Storage) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	const op = "internal.repository.postgres.subscription.ListSubscriptions"
+
+	builder := sq.Select("id", "url", "secret", "event_types", "created_at").
+		PlaceholderFormat(sq.Dollar).
+		From("subscriptions").
+		OrderBy("id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var subscriptions []*entity.Subscription
+	err = pgxscan.Select(ctx, s.pgxPool, &subscriptions, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainSubscriptionsFromEntity(subscriptions), nil
+}
+
+// DeleteSubscription removes the subscription together with its delivery
+// log.
+func (s *Storage) DeleteSubscription(ctx context.Context, id int64) error {
+	const op = "internal.repository.postgres.subscription.DeleteSubscription"
+
+	builder := sq.Delete("subscriptions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": id})
+	affected, err := s.execAudited(
+		ctx, domain.AuditActionSubscriptionDelete, domain.AuditEntitySubscription, strconv.FormatInt(id, 10), builder,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrSubscriptionNotFound)
+	}
+
+	return nil
+}
+
+// GetSubscriptionDeliveries returns the latest deliveries of the
+// subscription, newest first.
+func (s *Storage) GetSubscriptionDeliveries(
+	ctx context.Context,
+	subscriptionId int64,
+	limit int,
+) ([]*domain.SubscriptionDelivery, error) {
+	const op = "internal.repository.postgres.subscription.GetSubscriptionDeliveries"
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM subscription_deliveries d
+		JOIN subscriptions s ON s.id = d.subscription_id
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.subscription_id = $1
+		ORDER BY d.id DESC
+		LIMIT $2`
+
+	var deliveries []*entity.SubscriptionDelivery
+	err := pgxscan.Select(ctx, s.pgxPool, &deliveries, query, subscriptionId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainSubscriptionDeliveriesFromEntity(deliveries), nil
+}
@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"slices"
+	"strconv"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/moremoneymod/pr-reviewer/internal/repository"
 	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
@@ -23,10 +26,22 @@ func (s *Storage) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Te
 	}
 	defer tx.Rollback(ctx)
 
+	change, err := startAudit(ctx, tx, domain.AuditActionTeamCreate, domain.AuditEntityTeam, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	parentId, err := resolveParent(ctx, tx, team.ParentName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slaMinutes, fallback := converter.ToEntityTeamSettingsFromDomain(team.Settings)
+
 	teamBuilder := sq.Insert("teams").
 		PlaceholderFormat(sq.Dollar).
-		Columns("name").
-		Values(team.Name).
+		Columns("name", "parent_id", "review_sla_minutes", "reviewer_fallback").
+		Values(team.Name, nullableTeamID(parentId), slaMinutes, fallback).
 		Suffix("RETURNING id")
 
 	teamQuery, args, err := teamBuilder.ToSql()
@@ -44,38 +59,549 @@ func (s *Storage) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Te
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, member := range team.Members {
+	existing, err := addMembers(ctx, tx, team.ID, team.Members)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change.entityId = strconv.Itoa(team.ID)
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := s.GetTeamById(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	created.ExistingUsers = existing
+
+	return created, nil
+}
+
+func (s *Storage) UpdateTeam(ctx context.Context, teamName string, update domain.TeamUpdate) (*domain.Team, error) {
+	const op = "internal.repository.postgres.team.UpdateTeam"
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	teamId, err := lockTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change, err := startAudit(ctx, tx, domain.AuditActionTeamUpdate, domain.AuditEntityTeam, strconv.Itoa(teamId))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updateBuilder := sq.Update("teams").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": teamId})
+	changed := false
+
+	if update.NewName != "" && update.NewName != teamName {
+		updateBuilder = updateBuilder.Set("name", update.NewName)
+		changed = true
+	}
+
+	if update.ParentName != nil {
+		parentId, err := resolveParent(ctx, tx, *update.ParentName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if parentId != 0 {
+			chain, err := getTeamChain(ctx, tx, parentId)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			for _, ancestor := range chain {
+				if ancestor.ID == teamId {
+					return nil, fmt.Errorf("%s: %w", op, repository.ErrTeamCycle)
+				}
+			}
+		}
+
+		updateBuilder = updateBuilder.Set("parent_id", nullableTeamID(parentId))
+		changed = true
+	}
+
+	if update.Settings != nil {
+		slaMinutes, fallback := converter.ToEntityTeamSettingsFromDomain(*update.Settings)
+		updateBuilder = updateBuilder.
+			Set("review_sla_minutes", slaMinutes).
+			Set("reviewer_fallback", fallback)
+		changed = true
+	}
+
+	if changed {
+		query, args, err := updateBuilder.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == "23505" {
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrTeamExists)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if len(update.RemoveMembers) > 0 {
+		removeBuilder := sq.Delete("team_members").
+			PlaceholderFormat(sq.Dollar).
+			Where(sq.Eq{"team_id": teamId}).
+			Where(sq.Eq{"user_id": update.RemoveMembers}).
+			Suffix("RETURNING user_id")
+		query, args, err := removeBuilder.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var removed []string
+		err = pgxscan.Select(ctx, tx, &removed, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, userId := range update.RemoveMembers {
+			if !slices.Contains(removed, userId) {
+				return nil, fmt.Errorf("%s: %w", op, repository.ErrNotTeamMember)
+			}
+		}
+
+		err = applyOpenReviewsPolicy(ctx, tx, teamId, update.RemoveMembers, update.OpenReviewsPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	existing, err := addMembers(ctx, tx, teamId, update.AddMembers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := s.GetTeamById(ctx, teamId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated.ExistingUsers = existing
+
+	return updated, nil
+}
+
+func (s *Storage) DeleteTeam(
+	ctx context.Context,
+	teamName string,
+	policy domain.OpenReviewsPolicy,
+) (*domain.Team, error) {
+	const op = "internal.repository.postgres.team.DeleteTeam"
+
+	team, err := s.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	teamId, err := lockTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change, err := startAudit(ctx, tx, domain.AuditActionTeamDelete, domain.AuditEntityTeam, strconv.Itoa(teamId))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	memberIdsBuilder := sq.Select("user_id").
+		PlaceholderFormat(sq.Dollar).
+		From("team_members").
+		Where(sq.Eq{"team_id": teamId})
+	query, args, err := memberIdsBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var memberIds []string
+	err = pgxscan.Select(ctx, tx, &memberIds, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = applyOpenReviewsPolicy(ctx, tx, teamId, memberIds, policy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = handOverOpenPRs(ctx, tx, teamId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleteBuilder := sq.Delete("teams").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": teamId})
+	query, args, err = deleteBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return team, tx.Commit(ctx)
+}
+
+// handOverOpenPRs moves the team's open PRs to its parent team, so that they
+// keep a team to pick reviewers from. A team without a parent cannot be
+// deleted while it has open PRs.
+func handOverOpenPRs(ctx context.Context, tx pgx.Tx, teamId int) error {
+	const op = "internal.repository.postgres.team.handOverOpenPRs"
+
+	parentBuilder := sq.Select("parent_id").
+		PlaceholderFormat(sq.Dollar).
+		From("teams").
+		Where(sq.Eq{"id": teamId})
+	query, args, err := parentBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var parentId *int
+	err = tx.QueryRow(ctx, query, args...).Scan(&parentId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if parentId == nil {
+		openPRsBuilder := sq.Select("COUNT(*)").
+			PlaceholderFormat(sq.Dollar).
+			From("pull_requests").
+			Where(sq.Eq{"team_id": teamId, "status": "OPEN"})
+		query, args, err = openPRsBuilder.ToSql()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		var openPRs int
+		err = tx.QueryRow(ctx, query, args...).Scan(&openPRs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if openPRs > 0 {
+			return fmt.Errorf("%s: %w", op, repository.ErrTeamHasOpenPRs)
+		}
+
+		return nil
+	}
+
+	updateBuilder := sq.Update("pull_requests").
+		PlaceholderFormat(sq.Dollar).
+		Set("team_id", *parentId).
+		Where(sq.Eq{"team_id": teamId, "status": "OPEN"})
+	query, args, err = updateBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// resolveParent returns the ID of the named parent team, or 0 for an empty
+// name.
+func resolveParent(ctx context.Context, tx pgx.Tx, parentName string) (int, error) {
+	const op = "internal.repository.postgres.team.resolveParent"
+
+	if parentName == "" {
+		return 0, nil
+	}
+
+	builder := sq.Select("id").
+		PlaceholderFormat(sq.Dollar).
+		From("teams").
+		Where(sq.Eq{"name": parentName})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var parentId int
+	err = tx.QueryRow(ctx, query, args...).Scan(&parentId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, repository.ErrParentNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return parentId, nil
+}
+
+func lockTeam(ctx context.Context, tx pgx.Tx, teamName string) (int, error) {
+	const op = "internal.repository.postgres.team.lockTeam"
+
+	builder := sq.Select("id").
+		PlaceholderFormat(sq.Dollar).
+		From("teams").
+		Where(sq.Eq{"name": teamName}).
+		Suffix("FOR UPDATE")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var teamId int
+	err = tx.QueryRow(ctx, query, args...).Scan(&teamId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", op, repository.ErrTeamNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return teamId, nil
+}
+
+// addMembers registers the members that are new users and adds everyone to
+// the team. Existing users join as they are: their name and activity only
+// change through the user endpoints. It returns the IDs of the existing ones.
+// Memberships in other teams are left untouched.
+func addMembers(ctx context.Context, tx pgx.Tx, teamId int, members []domain.Member) ([]string, error) {
+	const op = "internal.repository.postgres.team.addMembers"
+
+	var existing []string
+	for _, member := range members {
 		userBuilder := sq.Insert("users").
 			PlaceholderFormat(sq.Dollar).
-			Columns("id", "username", "team_id", "is_active").
-			Values(member.UserID, member.Username, team.ID, member.IsActive).
-			Suffix(`
-                ON CONFLICT (id) DO UPDATE SET
-                    username = EXCLUDED.username,
-                    team_id = EXCLUDED.team_id,
-                    is_active = EXCLUDED.is_active;
-            `)
+			Columns("id", "username", "is_active").
+			Values(member.UserID, member.Username, member.IsActive).
+			Suffix("ON CONFLICT (id) DO NOTHING RETURNING id")
 		userQuery, args, err := userBuilder.ToSql()
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		_, err = tx.Exec(ctx, userQuery, args...)
+		var userId string
+		err = tx.QueryRow(ctx, userQuery, args...).Scan(&userId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			existing = append(existing, member.UserID)
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = addMembership(ctx, tx, teamId, member.UserID)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	return team, tx.Commit(ctx)
+	return existing, nil
+}
+
+func addMembership(ctx context.Context, tx pgx.Tx, teamId int, userId string) error {
+	const op = "internal.repository.postgres.team.addMembership"
+
+	builder := sq.Insert("team_members").
+		PlaceholderFormat(sq.Dollar).
+		Columns("team_id", "user_id").
+		Values(teamId, userId).
+		Suffix("ON CONFLICT (team_id, user_id) DO NOTHING")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// applyOpenReviewsPolicy handles open reviews on the team's PRs of users who
+// are leaving the team: it either refuses the change, drops their open
+// assignments or leaves them. Dropped assignments are not replaced, so the
+// PRs may be left with fewer reviewers than required.
+func applyOpenReviewsPolicy(
+	ctx context.Context,
+	tx pgx.Tx,
+	teamId int,
+	userIds []string,
+	policy domain.OpenReviewsPolicy,
+) error {
+	const op = "internal.repository.postgres.team.applyOpenReviewsPolicy"
+
+	if len(userIds) == 0 || policy == domain.OpenReviewsKeep {
+		return nil
+	}
+
+	openPRs := sq.Select("id").
+		From("pull_requests").
+		Where(sq.Eq{"status": "OPEN"}).
+		Where(sq.Eq{"team_id": teamId})
+
+	if policy == domain.OpenReviewsUnassign {
+		err := unassignOpenReviews(ctx, tx, userIds, openPRs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	}
+
+	builder := sq.Select("COUNT(*)").
+		PlaceholderFormat(sq.Dollar).
+		From("pr_reviewers").
+		Where(sq.Eq{"user_id": userIds}).
+		Where(sq.Eq{"unassigned_at": nil}).
+		Where(sq.Expr("pr_id IN (?)", openPRs))
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var openReviews int
+	err = tx.QueryRow(ctx, query, args...).Scan(&openReviews)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if openReviews > 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrOpenReviews)
+	}
+
+	return nil
+}
+
+// unassignOpenReviews removes the users from the open PRs without a
+// replacement. Like a reassignment, every PR whose reviewers change is
+// audited and gets an outbox event for each removed reviewer.
+func unassignOpenReviews(ctx context.Context, tx pgx.Tx, userIds []string, openPRs sq.SelectBuilder) error {
+	const op = "internal.repository.postgres.team.unassignOpenReviews"
+
+	builder := sq.Select("pr_id", "user_id").
+		PlaceholderFormat(sq.Dollar).
+		From("pr_reviewers").
+		Where(sq.Eq{"user_id": userIds}).
+		Where(sq.Eq{"unassigned_at": nil}).
+		Where(sq.Expr("pr_id IN (?)", openPRs)).
+		OrderBy("pr_id", "user_id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var reviews []*entity.Reviewer
+	err = pgxscan.Select(ctx, tx, &reviews, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	removed := make(map[string][]string)
+	var prIds []string
+	for _, review := range reviews {
+		if _, ok := removed[review.PRID]; !ok {
+			prIds = append(prIds, review.PRID)
+		}
+		removed[review.PRID] = append(removed[review.PRID], review.UserID)
+	}
+
+	for _, prId := range prIds {
+		change, err := startAudit(ctx, tx, domain.AuditActionPRUnassign, domain.AuditEntityPR, prId)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		unassignBuilder := sq.Update("pr_reviewers").
+			PlaceholderFormat(sq.Dollar).
+			Set("unassigned_at", sq.Expr("NOW()")).
+			Set("unassign_reason", domain.UnassignReasonLeftTeam).
+			Where(sq.Eq{"pr_id": prId}).
+			Where(sq.Eq{"user_id": removed[prId]}).
+			Where(sq.Eq{"unassigned_at": nil})
+		query, args, err := unassignBuilder.ToSql()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		events := make([]domain.PREvent, len(removed[prId]))
+		for i, userId := range removed[prId] {
+			events[i] = domain.PREvent{
+				Type:   domain.PREventReviewerRemoved,
+				UserID: userId,
+				Reason: domain.UnassignReasonLeftTeam,
+			}
+		}
+		err = insertPREvents(ctx, tx, prId, events...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = change.finish(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, userId := range removed[prId] {
+			err = insertOutboxEvent(ctx, tx, domain.EventReviewerUnassigned, prId, userId, "")
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	return nil
 }
 
 func (s *Storage) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	const op = "internal.repository.postgres.team.GetTeam"
 
-	teamBuilder := sq.Select("id", "name", "created_at").
-		PlaceholderFormat(sq.Dollar).
-		From("teams").
-		Where(sq.Eq{"name": teamName})
+	teamBuilder := teamSelect().
+		Where(sq.Eq{"t.name": teamName})
 	teamQuery, args, err := teamBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -90,83 +616,94 @@ func (s *Storage) GetTeam(ctx context.Context, teamName string) (*domain.Team, e
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	membersBuilder := sq.Select("id", "username", "team_id", "is_active", "created_at").
-		PlaceholderFormat(sq.Dollar).
-		From("users").
-		Where(sq.Eq{"team_id": team.ID})
-	membersQuery, args, err := membersBuilder.ToSql()
+	members, err := s.getMembers(ctx, team.ID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var members []entity.Member
-	if err := pgxscan.Select(ctx, s.pgxPool, &members, membersQuery, args...); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
 	team.Members = members
 
 	return converter.ToDomainTeamFromEntity(&team), nil
 }
 
-func (s *Storage) GetAllTeam(ctx context.Context) ([]*domain.Team, error) {
+// GetAllTeam returns a page of teams ordered by name together with their
+// member counts.
+func (s *Storage) GetAllTeam(ctx context.Context, filter domain.TeamFilter) (*domain.TeamPage, error) {
 	const op = "internal.repository.postgres.team.GetAllTeam"
 
-	builder := sq.Select(
-		"t.id",
-		"t.name",
-		"t.created_at",
-		"COALESCE(json_agg(json_build_object("+
-			"'user_id', u.id, "+
-			"'username', u.username, "+
-			"'team_id', u.team_id, "+
-			"'is_active', u.is_active, "+
-			"'created_at', u.created_at"+
-			")) FILTER (WHERE u.id IS NOT NULL), '[]') as members",
-	).
+	var where sq.Sqlizer = sq.Expr("TRUE")
+	if filter.Name != "" {
+		where = sq.ILike{"t.name": "%" + likeEscaper.Replace(filter.Name) + "%"}
+	}
+
+	countBuilder := sq.Select("COUNT(*)").
 		PlaceholderFormat(sq.Dollar).
 		From("teams t").
-		LeftJoin("users u ON t.id = u.team_id").
-		GroupBy("t.id, t.name, t.created_at").
-		OrderBy("t.created_at DESC")
+		Where(where)
+	query, args, err := countBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	query, args, err := builder.ToSql()
+	page := &domain.TeamPage{
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	err = s.pgxPool.QueryRow(ctx, query, args...).Scan(&page.Total)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	type teamRow struct {
-		ID        int             `db:"id"`
-		Name      string          `db:"name"`
-		CreatedAt time.Time       `db:"created_at"`
-		Members   []entity.Member `db:"members"`
+	builder := teamSelect().
+		Columns(
+			"(SELECT COUNT(*) FROM team_members tm WHERE tm.team_id = t.id) AS member_count",
+			`(SELECT COUNT(*)
+              FROM team_members tm
+              JOIN users u ON u.id = tm.user_id
+              WHERE tm.team_id = t.id AND u.is_active) AS active_member_count`,
+		).
+		Where(where).
+		OrderBy("t.name").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset))
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var rows []teamRow
+	var rows []*entity.TeamSummary
 	err = pgxscan.Select(ctx, s.pgxPool, &rows, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	teams := make([]*domain.Team, len(rows))
-	for i, row := range rows {
-		teams[i] = &domain.Team{
-			ID:      row.ID,
-			Name:    row.Name,
-			Members: converter.ToDomainMembersFromEntity(row.Members),
+	members := map[int][]entity.Member{}
+	if filter.WithMembers {
+		teamIds := make([]int, len(rows))
+		for i, row := range rows {
+			teamIds[i] = row.ID
+		}
+
+		members, err = s.getTeamsMembers(ctx, teamIds)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	return teams, nil
+	page.Teams = make([]*domain.Team, len(rows))
+	for i, row := range rows {
+		row.Members = members[row.ID]
+		page.Teams[i] = converter.ToDomainTeamSummaryFromEntity(row)
+	}
+
+	return page, nil
 }
 
 func (s *Storage) GetTeamById(ctx context.Context, teamId int) (*domain.Team, error) {
 	const op = "internal.repository.postgres.team.GetTeam"
 
-	teamBuilder := sq.Select("id", "name", "created_at").
-		PlaceholderFormat(sq.Dollar).
-		From("teams").
-		Where(sq.Eq{"id": teamId})
+	teamBuilder := teamSelect().
+		Where(sq.Eq{"t.id": teamId})
 	teamQuery, args, err := teamBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -181,10 +718,161 @@ func (s *Storage) GetTeamById(ctx context.Context, teamId int) (*domain.Team, er
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	membersBuilder := sq.Select("id", "username", "team_id", "is_active", "created_at").
+	members, err := s.getMembers(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team.Members = members
+
+	return converter.ToDomainTeamFromEntity(&team), nil
+}
+
+// GetTeamChain returns the team followed by its ancestors, nearest first.
+// Members are not loaded.
+func (s *Storage) GetTeamChain(ctx context.Context, teamId int) ([]*domain.Team, error) {
+	const op = "internal.repository.postgres.team.GetTeamChain"
+
+	chain, err := getTeamChain(ctx, s.pgxPool, teamId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	teams := make([]*domain.Team, len(chain))
+	for i, team := range chain {
+		teams[i] = converter.ToDomainTeamFromEntity(team)
+	}
+
+	return teams, nil
+}
+
+func getTeamChain(ctx context.Context, db pgxscan.Querier, teamId int) ([]*entity.Team, error) {
+	const op = "internal.repository.postgres.team.getTeamChain"
+
+	query := `
+        WITH RECURSIVE chain AS (
+            SELECT id, parent_id, 0 AS depth
+            FROM teams
+            WHERE id = $1
+            UNION ALL
+            SELECT t.id, t.parent_id, c.depth + 1
+            FROM teams t
+            JOIN chain c ON t.id = c.parent_id
+        ) CYCLE id SET is_cycle USING path
+        SELECT t.id, t.name, t.created_at,
+               COALESCE(t.parent_id, 0) AS parent_id,
+               COALESCE(p.name, '') AS parent_name,
+               t.review_sla_minutes, t.reviewer_fallback
+        FROM chain c
+        JOIN teams t ON t.id = c.id
+        LEFT JOIN teams p ON p.id = t.parent_id
+        WHERE NOT c.is_cycle
+        ORDER BY c.depth
+    `
+
+	var chain []*entity.Team
+	err := pgxscan.Select(ctx, db, &chain, query, teamId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chain, nil
+}
+
+// GetTeamTree returns every team with its member count and the open reviews
+// assigned to its members, alone and together with all descendant teams.
+func (s *Storage) GetTeamTree(ctx context.Context) ([]*domain.TeamNode, error) {
+	const op = "internal.repository.postgres.team.GetTeamTree"
+
+	query := `
+        WITH RECURSIVE closure AS (
+            SELECT id AS ancestor_id, id AS descendant_id
+            FROM teams
+            UNION ALL
+            SELECT c.ancestor_id, t.id
+            FROM closure c
+            JOIN teams t ON t.parent_id = c.descendant_id
+        ) CYCLE descendant_id SET is_cycle USING path,
+        subtree_users AS (
+            SELECT DISTINCT c.ancestor_id AS team_id, tm.user_id
+            FROM closure c
+            JOIN team_members tm ON tm.team_id = c.descendant_id
+            WHERE NOT c.is_cycle
+        ),
+        open_load AS (
+            SELECT prw.user_id, COUNT(*) AS open_reviews
+            FROM pr_reviewers prw
+            JOIN pull_requests pr ON pr.id = prw.pr_id
+            WHERE pr.status = 'OPEN' AND prw.unassigned_at IS NULL
+            GROUP BY prw.user_id
+        )
+        SELECT t.id, t.name,
+               COALESCE(t.parent_id, 0) AS parent_id,
+               (SELECT COUNT(*) FROM team_members tm WHERE tm.team_id = t.id) AS member_count,
+               (SELECT COUNT(*) FROM subtree_users su WHERE su.team_id = t.id) AS total_member_count,
+               (SELECT COALESCE(SUM(ol.open_reviews), 0)
+                FROM team_members tm
+                JOIN open_load ol ON ol.user_id = tm.user_id
+                WHERE tm.team_id = t.id) AS open_reviews,
+               (SELECT COALESCE(SUM(ol.open_reviews), 0)
+                FROM subtree_users su
+                JOIN open_load ol ON ol.user_id = su.user_id
+                WHERE su.team_id = t.id) AS total_open_reviews
+        FROM teams t
+        ORDER BY t.name
+    `
+
+	var nodes []*entity.TeamNode
+	err := pgxscan.Select(ctx, s.pgxPool, &nodes, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainTeamNodesFromEntity(nodes), nil
+}
+
+func teamSelect() sq.SelectBuilder {
+	return sq.Select(
+		"t.id", "t.name", "t.created_at",
+		"COALESCE(t.parent_id, 0) AS parent_id",
+		"COALESCE(p.name, '') AS parent_name",
+		"t.review_sla_minutes", "t.reviewer_fallback",
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("teams t").
+		LeftJoin("teams p ON p.id = t.parent_id")
+}
+
+func (s *Storage) getMembers(ctx context.Context, teamId int) ([]entity.Member, error) {
+	const op = "internal.repository.postgres.team.getMembers"
+
+	members, err := s.getTeamsMembers(ctx, []int{teamId})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members[teamId], nil
+}
+
+// getTeamsMembers returns the members of the teams keyed by team ID, each
+// with all of their memberships.
+func (s *Storage) getTeamsMembers(ctx context.Context, teamIds []int) (map[int][]entity.Member, error) {
+	const op = "internal.repository.postgres.team.getTeamsMembers"
+
+	result := make(map[int][]entity.Member, len(teamIds))
+	if len(teamIds) == 0 {
+		return result, nil
+	}
+
+	membersBuilder := sq.Select(
+		"u.id", "u.username", "tm.team_id", "u.is_active",
+		"u.timezone", "u.work_start", "u.work_end", "u.created_at",
+	).
 		PlaceholderFormat(sq.Dollar).
-		From("users").
-		Where(sq.Eq{"team_id": team.ID})
+		From("users u").
+		Join("team_members tm ON tm.user_id = u.id").
+		Where(sq.Eq{"tm.team_id": teamIds}).
+		OrderBy("u.id")
 	membersQuery, args, err := membersBuilder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -195,9 +883,55 @@ func (s *Storage) GetTeamById(ctx context.Context, teamId int) (*domain.Team, er
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	team.Members = members
+	userIds := make([]string, 0, len(members))
+	for _, member := range members {
+		userIds = append(userIds, member.UserID)
+	}
 
-	return converter.ToDomainTeamFromEntity(&team), nil
+	memberships, err := s.getMemberships(ctx, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, member := range members {
+		member.Teams = memberships[member.UserID]
+		result[member.TeamID] = append(result[member.TeamID], member)
+	}
+
+	return result, nil
+}
+
+// getMemberships returns all team memberships of the users keyed by user ID.
+func (s *Storage) getMemberships(ctx context.Context, userIds []string) (map[string][]entity.TeamMembership, error) {
+	const op = "internal.repository.postgres.team.getMemberships"
+
+	memberships := make(map[string][]entity.TeamMembership, len(userIds))
+	if len(userIds) == 0 {
+		return memberships, nil
+	}
+
+	builder := sq.Select("tm.user_id", "t.id AS team_id", "t.name AS team_name", "tm.role").
+		PlaceholderFormat(sq.Dollar).
+		From("team_members tm").
+		Join("teams t ON t.id = tm.team_id").
+		Where(sq.Eq{"tm.user_id": userIds}).
+		OrderBy("t.name")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var rows []entity.TeamMembership
+	err = pgxscan.Select(ctx, s.pgxPool, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, row := range rows {
+		memberships[row.UserID] = append(memberships[row.UserID], row)
+	}
+
+	return memberships, nil
 }
 
 func (s *Storage) GetTeamStatistics(ctx context.Context) (*domain.TeamStatistics, error) {
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
+	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
+	domain "github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const tokenColumns = "id, name, prefix, scopes, created_at, last_used_at, revoked_at"
+
+// tokenTouchInterval limits how often a token's last use is written.
+const tokenTouchInterval = time.Minute
+
+// CreateAPIToken stores the token under its hash.
+func (s *Storage) CreateAPIToken(ctx context.Context, token domain.APIToken, hash string) (*domain.APIToken, error) {
+	const op = "internal.repository.postgres.token.CreateAPIToken"
+
+	builder := tokenInsert(token, hash).
+		Suffix("RETURNING " + tokenColumns)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionTokenCreate, domain.AuditEntityToken, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tokenEntity entity.APIToken
+	err = pgxscan.Get(ctx, tx, &tokenEntity, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change.entityId = strconv.FormatInt(tokenEntity.ID, 10)
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainAPITokenFromEntity(&tokenEntity), nil
+}
+
+// EnsureAPIToken stores the token unless a token with the same hash exists,
+// whether or not it has been revoked since.
+func (s *Storage) EnsureAPIToken(ctx context.Context, token domain.APIToken, hash string) error {
+	const op = "internal.repository.postgres.token.EnsureAPIToken"
+
+	builder := tokenInsert(token, hash).
+		Suffix("ON CONFLICT (token_hash) DO NOTHING RETURNING id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionTokenCreate, domain.AuditEntityToken, "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, query, args...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	change.entityId = strconv.FormatInt(id, 10)
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *Storage) ListAPITokens(ctx context.Context) ([]*domain.APIToken, error) {
+	const op = "internal.repository.postgres.token.ListAPITokens"
+
+	builder := sq.Select(tokenColumns).
+		PlaceholderFormat(sq.Dollar).
+		From("api_tokens").
+		OrderBy("id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tokens []*entity.APIToken
+	err = pgxscan.Select(ctx, s.pgxPool, &tokens, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainAPITokensFromEntity(tokens), nil
+}
+
+// RevokeAPIToken revokes the token. Revoking a revoked token keeps its
+// original revocation time.
+func (s *Storage) RevokeAPIToken(ctx context.Context, id int64) (*domain.APIToken, error) {
+	const op = "internal.repository.postgres.token.RevokeAPIToken"
+
+	builder := sq.Update("api_tokens").
+		PlaceholderFormat(sq.Dollar).
+		Set("revoked_at", sq.Expr("COALESCE(revoked_at, NOW())")).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING " + tokenColumns)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionTokenRevoke, domain.AuditEntityToken, strconv.FormatInt(id, 10))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tokenEntity entity.APIToken
+	err = pgxscan.Get(ctx, tx, &tokenEntity, query, args...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrTokenNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainAPITokenFromEntity(&tokenEntity), nil
+}
+
+// UseAPIToken returns the unrevoked token with the hash and records that it
+// was used.
+func (s *Storage) UseAPIToken(ctx context.Context, hash string) (*domain.APIToken, error) {
+	const op = "internal.repository.postgres.token.UseAPIToken"
+
+	builder := sq.Select(tokenColumns).
+		PlaceholderFormat(sq.Dollar).
+		From("api_tokens").
+		Where(sq.Eq{"token_hash": hash}).
+		Where(sq.Eq{"revoked_at": nil})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tokenEntity entity.APIToken
+	err = pgxscan.Get(ctx, s.pgxPool, &tokenEntity, query, args...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrTokenNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	touchBuilder := sq.Update("api_tokens").
+		PlaceholderFormat(sq.Dollar).
+		Set("last_used_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": tokenEntity.ID}).
+		Where(sq.Or{
+			sq.Eq{"last_used_at": nil},
+			sq.Expr("last_used_at < NOW() - make_interval(secs => ?)", tokenTouchInterval.Seconds()),
+		})
+	query, args, err = touchBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainAPITokenFromEntity(&tokenEntity), nil
+}
+
+func tokenInsert(token domain.APIToken, hash string) sq.InsertBuilder {
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return sq.Insert("api_tokens").
+		PlaceholderFormat(sq.Dollar).
+		Columns("name", "token_hash", "prefix", "scopes").
+		Values(token.Name, hash, token.Prefix, scopes)
+}
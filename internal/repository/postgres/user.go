@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/moremoneymod/pr-reviewer/internal/repository"
 	"github.com/moremoneymod/pr-reviewer/internal/repository/converter"
 	entity "github.com/moremoneymod/pr-reviewer/internal/repository/entity"
@@ -20,17 +23,120 @@ func (s *Storage) SetIsActive(ctx context.Context, userId string, isActive bool)
 		Set("is_active", isActive).
 		Where(sq.Eq{"id": userId})
 
-	query, args, err := builder.ToSql()
+	affected, err := s.execAudited(ctx, domain.AuditActionUserSetActive, domain.AuditEntityUser, userId, builder)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected != 1 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return user, nil
+}
+
+func (s *Storage) SetRole(ctx context.Context, userId string, role domain.Role) (*domain.User, error) {
+	const op = "internal.repository.postgres.user.SetRole"
+
+	builder := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("role", string(role)).
+		Where(sq.Eq{"id": userId})
+	affected, err := s.execAudited(ctx, domain.AuditActionUserSetRole, domain.AuditEntityUser, userId, builder)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// SetTeamRole sets the user's role in one of their teams.
+func (s *Storage) SetTeamRole(ctx context.Context, userId string, teamId int, role domain.Role) (*domain.User, error) {
+	const op = "internal.repository.postgres.user.SetTeamRole"
+
+	builder := sq.Update("team_members").
+		PlaceholderFormat(sq.Dollar).
+		Set("role", string(role)).
+		Where(sq.Eq{"team_id": teamId}).
+		Where(sq.Eq{"user_id": userId})
+	affected, err := s.execAudited(ctx, domain.AuditActionUserSetTeamRole, domain.AuditEntityUser, userId, builder)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrNotTeamMember)
+	}
+
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) SetWorkingHours(
+	ctx context.Context,
+	userId string,
+	workingHours domain.WorkingHours,
+) (*domain.User, error) {
+	const op = "internal.repository.postgres.user.SetWorkingHours"
+
+	builder := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("timezone", workingHours.Timezone).
+		Set("work_start", workingHours.StartHour).
+		Set("work_end", workingHours.EndHour).
+		Where(sq.Eq{"id": userId})
+
+	affected, err := s.execAudited(ctx, domain.AuditActionUserSetWorkHours, domain.AuditEntityUser, userId, builder)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	result, err := s.pgxPool.Exec(ctx, query, args...)
+	if affected != 1 {
+		return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return user, nil
+}
+
+func (s *Storage) SetEmail(ctx context.Context, userId string, settings domain.EmailSettings) (*domain.User, error) {
+	const op = "internal.repository.postgres.user.SetEmail"
+
+	var email any
+	if settings.Email != "" {
+		email = settings.Email
+	}
+
+	builder := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("email", email).
+		Where(sq.Eq{"id": userId})
+	if settings.DigestEnabled != nil {
+		builder = builder.Set("digest_enabled", *settings.DigestEnabled)
+	}
+
+	affected, err := s.execAudited(ctx, domain.AuditActionUserSetEmail, domain.AuditEntityUser, userId, builder)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if result.RowsAffected() != 1 {
+	if affected != 1 {
 		return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
 	}
 	user, err := s.GetUser(ctx, userId)
@@ -40,16 +146,132 @@ func (s *Storage) SetIsActive(ctx context.Context, userId string, isActive bool)
 	return user, nil
 }
 
-func (s *Storage) GetReview(ctx context.Context, prIds []string) ([]*domain.PRShort, error) {
+// GetDigestRecipients returns the active users with an email, including
+// those who opted out of the digest.
+func (s *Storage) GetDigestRecipients(ctx context.Context) ([]*domain.DigestRecipient, error) {
+	const op = "internal.repository.postgres.user.GetDigestRecipients"
+
+	builder := sq.Select("id", "username", "email", "timezone", "work_start", "work_end", "digest_sent_at", "digest_enabled").
+		PlaceholderFormat(sq.Dollar).
+		From("users").
+		Where(sq.Eq{"is_active": true}).
+		Where(sq.NotEq{"email": nil}).
+		OrderBy("id")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var recipients []*entity.DigestRecipient
+	err = pgxscan.Select(ctx, s.pgxPool, &recipients, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToDomainDigestRecipientsFromEntity(recipients), nil
+}
+
+func (s *Storage) MarkDigestSent(ctx context.Context, userId string) error {
+	const op = "internal.repository.postgres.user.MarkDigestSent"
+
+	builder := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("digest_sent_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": userId})
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.pgxPool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MoveUser replaces the user's membership in fromTeamId with one in toTeamId,
+// applying the replacements and then the policy to their open reviews on the
+// old team's PRs in the same transaction. A zero fromTeamId only adds the new
+// membership.
+func (s *Storage) MoveUser(
+	ctx context.Context,
+	userId string,
+	fromTeamId int,
+	toTeamId int,
+	policy domain.OpenReviewsPolicy,
+	replacements []domain.ReviewerReplacement,
+) error {
+	const op = "internal.repository.postgres.user.MoveUser"
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := startAudit(ctx, tx, domain.AuditActionUserMoveTeam, domain.AuditEntityUser, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, replacement := range replacements {
+		err = replaceReviewer(ctx, tx, replacement)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if fromTeamId != 0 {
+		err = applyOpenReviewsPolicy(ctx, tx, fromTeamId, []string{userId}, policy)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		builder := sq.Delete("team_members").
+			PlaceholderFormat(sq.Dollar).
+			Where(sq.Eq{"team_id": fromTeamId}).
+			Where(sq.Eq{"user_id": userId})
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = addMembership(ctx, tx, toTeamId, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *Storage) GetReview(ctx context.Context, reviewerId string) ([]*domain.PRShort, error) {
 	const op = "internal.repository.postgres.user.GetReview"
 
-	builder := sq.Select("id", "name", "author_id", "status").
+	builder := sq.Select(
+		"pr.id", "pr.name", "pr.author_id", "pr.status", "COALESCE(pr.team_id, 0) AS team_id", "prw.assigned_at",
+	).
 		PlaceholderFormat(sq.Dollar).
-		From("pull_requests").
-		Where(sq.Eq{"id": prIds})
+		From("pull_requests pr").
+		Join("pr_reviewers prw ON prw.pr_id = pr.id").
+		Where(sq.Eq{"prw.user_id": reviewerId}).
+		Where(sq.Eq{"prw.unassigned_at": nil}).
+		OrderBy("prw.assigned_at")
 	query, args, err := builder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("%s%w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var result []*entity.PRShort
@@ -58,16 +280,14 @@ func (s *Storage) GetReview(ctx context.Context, prIds []string) ([]*domain.PRSh
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return converter.ToDomainPRShortsFromEntity(result), err
+	return converter.ToDomainPRShortsFromEntity(result), nil
 }
 
 func (s *Storage) GetUser(ctx context.Context, userId string) (*domain.User, error) {
 	const op = "internal.repository.postgres.user.GetUser"
 
-	builder := sq.Select("id", "username", "team_id", "is_active").
-		PlaceholderFormat(sq.Dollar).
-		From("users").
-		Where(sq.Eq{"id": userId})
+	builder := userSelect().
+		Where(sq.Eq{"u.id": userId})
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -82,55 +302,182 @@ func (s *Storage) GetUser(ctx context.Context, userId string) (*domain.User, err
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	memberships, err := s.getMemberships(ctx, []string{userId})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	result.Teams = memberships[userId]
+
 	return converter.ToDomainUserFromEntity(&result), nil
 }
 
-func (s *Storage) GetReviewers(ctx context.Context, teamId int, excludeUserIds []string, limit int) ([]string, error) {
-	const op = "internal.repository.postgres.user.GetReviewers"
+func (s *Storage) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	const op = "internal.repository.postgres.user.ListUsers"
 
-	builder := sq.Select("id").
-		PlaceholderFormat(sq.Dollar).
-		From("users").
-		Where(sq.Eq{"team_id": teamId}, sq.Eq{"is_active": true})
+	builder := userSelect().
+		OrderBy("u.id")
 
-	if len(excludeUserIds) > 0 {
-		for _, excludeUserId := range excludeUserIds {
-			builder = builder.Where(sq.NotEq{"id": excludeUserId})
-		}
+	if filter.TeamName != "" {
+		builder = builder.Where(sq.Expr(`EXISTS (
+            SELECT 1
+            FROM team_members tm
+            JOIN teams t ON t.id = tm.team_id
+            WHERE tm.user_id = u.id AND t.name = ?
+        )`, filter.TeamName))
 	}
-
-	if limit > 0 {
-		builder = builder.Limit(uint64(limit))
+	if filter.IsActive != nil {
+		builder = builder.Where(sq.Eq{"u.is_active": *filter.IsActive})
+	}
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		builder = builder.Where(sq.Or{
+			sq.ILike{"u.id": pattern},
+			sq.ILike{"u.username": pattern},
+		})
 	}
 
-	builder = builder.OrderBy("RANDOM()")
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var result []string
+	var result []*entity.User
 	err = pgxscan.Select(ctx, s.pgxPool, &result, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return result, nil
+	userIds := make([]string, len(result))
+	for i, user := range result {
+		userIds[i] = user.ID
+	}
+
+	memberships, err := s.getMemberships(ctx, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users := make([]*domain.User, len(result))
+	for i, user := range result {
+		user.Teams = memberships[user.ID]
+		users[i] = converter.ToDomainUserFromEntity(user)
+	}
+
+	return users, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// userSelect selects users together with the number of open PRs they
+// currently review.
+func userSelect() sq.SelectBuilder {
+	return sq.Select(
+		"u.id", "u.username", "u.is_active", "u.timezone", "u.work_start", "u.work_end",
+		"COALESCE(u.email, '') AS email", "u.digest_enabled", "u.role",
+		`(SELECT COUNT(*)
+          FROM pr_reviewers prw
+          JOIN pull_requests pr ON pr.id = prw.pr_id
+          WHERE prw.user_id = u.id AND prw.unassigned_at IS NULL AND pr.status = 'OPEN') AS open_reviews`,
+	).
+		PlaceholderFormat(sq.Dollar).
+		From("users u")
 }
 
-func (s *Storage) ReplaceReviewer(ctx context.Context, newReviewerId string, oldReviewerId string, prId string) error {
+// ReplaceReviewer ends the old reviewer's assignment with the reason and
+// starts a new one, so the PR keeps its reviewer history.
+func (s *Storage) ReplaceReviewer(ctx context.Context, replacement domain.ReviewerReplacement) error {
 	const op = "internal.repository.postgres.user.ReplaceReviewer"
 
-	builder := sq.Update("pr_reviewers").
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = replaceReviewer(ctx, tx, replacement)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceReviewer(ctx context.Context, tx pgx.Tx, replacement domain.ReviewerReplacement) error {
+	const op = "internal.repository.postgres.user.replaceReviewer"
+
+	change, err := startAudit(ctx, tx, domain.AuditActionPRReassign, domain.AuditEntityPR, replacement.PRID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	unassignBuilder := sq.Update("pr_reviewers").
 		PlaceholderFormat(sq.Dollar).
-		Where(sq.Eq{"pr_id": prId}).
-		Where(sq.Eq{"user_id": oldReviewerId}).
-		Set("user_id", newReviewerId)
-	query, args, err := builder.ToSql()
+		Where(sq.Eq{"pr_id": replacement.PRID}).
+		Where(sq.Eq{"user_id": replacement.OldReviewerID}).
+		Where(sq.Eq{"unassigned_at": nil}).
+		Set("unassigned_at", sq.Expr("NOW()")).
+		Set("unassign_reason", replacement.Reason)
+	query, args, err := unassignBuilder.ToSql()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	_, err = s.pgxPool.Exec(ctx, query, args...)
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrNotReviewer)
+	}
+
+	assignBuilder := sq.Insert("pr_reviewers").
+		PlaceholderFormat(sq.Dollar).
+		Columns("pr_id", "user_id").
+		Values(replacement.PRID, replacement.NewReviewerID)
+	query, args, err = assignBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		// A concurrent reassign picked the same reviewer first.
+		if pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, repository.ErrAlreadyReviewer)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, decision := range replacement.Decisions {
+		err = insertAssignmentLog(ctx, tx, decision)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = insertPREvents(ctx, tx, replacement.PRID,
+		domain.PREvent{
+			Type:   domain.PREventReviewerRemoved,
+			UserID: replacement.OldReviewerID,
+			Reason: replacement.Reason,
+		},
+		domain.PREvent{
+			Type:   domain.PREventReviewerAssigned,
+			UserID: replacement.NewReviewerID,
+			Reason: domain.AssignmentTriggerReassign,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = change.finish(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = insertOutboxEvent(ctx, tx, domain.EventReviewerReassigned,
+		replacement.PRID, replacement.OldReviewerID, replacement.NewReviewerID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -138,11 +485,19 @@ func (s *Storage) ReplaceReviewer(ctx context.Context, newReviewerId string, old
 	return nil
 }
 
-func (s *Storage) GetUserStatistics(ctx context.Context) (*domain.UserStatistics, error) {
+// GetUserStatistics counts the users, only the members of the filter's team
+// if any.
+func (s *Storage) GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.UserStatistics, error) {
 	const op = "internal.repository.postgres.user.GetUserStatistics"
 
 	builder := sq.Select("count(*) as total, count(CASE WHEN is_active = true THEN 1 END) as active").
+		PlaceholderFormat(sq.Dollar).
 		From("users")
+	if filter.TeamID != 0 {
+		builder = builder.Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM team_members tm WHERE tm.user_id = users.id AND tm.team_id = ?)", filter.TeamID,
+		))
+	}
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -156,17 +511,37 @@ func (s *Storage) GetUserStatistics(ctx context.Context) (*domain.UserStatistics
 	return converter.ToDomainUserStatisticsFromEntity(&userStatistics), nil
 }
 
-func (s *Storage) GetUserAssignmentStatistics(ctx context.Context) ([]domain.UserAssignmentStat, error) {
-	const op = "internal.repository.postgres.team.GetUserAssignmentStatistics"
+// GetUserAssignmentStatistics counts the users' current assignments on the
+// filter's team PRs made within its period. Only users with assignments are
+// listed unless the filter includes idle ones.
+func (s *Storage) GetUserAssignmentStatistics(
+	ctx context.Context,
+	filter domain.StatisticsFilter,
+) ([]domain.UserAssignmentStat, error) {
+	const op = "internal.repository.postgres.user.GetUserAssignmentStatistics"
+
+	scope, scopeArgs, err := statisticsScope(filter, "pr.team_id", "prw.assigned_at").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	builder := sq.Select("users.id as user_id, users.username, teams.name as team_name, COUNT(prw.id) as total_assignments, COUNT(CASE WHEN pr.status = 'OPEN' THEN 1 END) as open_assignments, COUNT(CASE WHEN pr.status = 'MERGED' THEN 1 END) as merged_assignments").
+	builder := sq.Select("users.id as user_id, users.username, COALESCE((SELECT string_agg(t.name, ', ' ORDER BY t.name) FROM team_members tm JOIN teams t ON t.id = tm.team_id WHERE tm.user_id = users.id), '') as team_name, COUNT(prw.pr_id) as total_assignments, COUNT(CASE WHEN pr.status = 'OPEN' THEN 1 END) as open_assignments, COUNT(CASE WHEN pr.status = 'MERGED' THEN 1 END) as merged_assignments").
+		PlaceholderFormat(sq.Dollar).
 		From("users").
-		LeftJoin("pr_reviewers prw ON users.id = prw.user_id").
-		LeftJoin("pull_requests pr ON prw.pr_id = pr.id").
-		LeftJoin("teams ON users.team_id = teams.id").
-		GroupBy("users.id", "users.username", "teams.name").
-		Having("COUNT(prw.id) > 0").
-		OrderBy("total_assignments DESC")
+		LeftJoin("(pr_reviewers prw JOIN pull_requests pr ON prw.pr_id = pr.id) "+
+			"ON users.id = prw.user_id AND prw.unassigned_at IS NULL AND "+scope, scopeArgs...).
+		GroupBy("users.id", "users.username").
+		OrderBy("total_assignments DESC", "users.id")
+	if filter.IncludeIdle {
+		builder = builder.Having("COUNT(prw.pr_id) > 0 OR users.is_active")
+		if filter.TeamID != 0 {
+			builder = builder.Where(sq.Expr(
+				"EXISTS (SELECT 1 FROM team_members tm WHERE tm.user_id = users.id AND tm.team_id = ?)", filter.TeamID,
+			))
+		}
+	} else {
+		builder = builder.Having("COUNT(prw.pr_id) > 0")
+	}
 
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -3,10 +3,19 @@ package repository
 import "errors"
 
 var (
-	ErrTeamExists         = errors.New("team already exists")
-	ErrTeamNotFound       = errors.New("team not found")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrPRExists           = errors.New("PR already exists")
-	ErrPRNotFound         = errors.New("PR not found")
-	ErrStatisticsNotFound = errors.New("statistics not found")
+	ErrTeamExists           = errors.New("team already exists")
+	ErrTeamNotFound         = errors.New("team not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrPRExists             = errors.New("PR already exists")
+	ErrPRNotFound           = errors.New("PR not found")
+	ErrStatisticsNotFound   = errors.New("statistics not found")
+	ErrOpenReviews          = errors.New("users have open reviews")
+	ErrParentNotFound       = errors.New("parent team not found")
+	ErrTeamCycle            = errors.New("team hierarchy cycle")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrTokenNotFound        = errors.New("API token not found")
+	ErrNotTeamMember        = errors.New("user is not a team member")
+	ErrNotReviewer          = errors.New("user is not a reviewer")
+	ErrAlreadyReviewer      = errors.New("user is already a reviewer")
+	ErrTeamHasOpenPRs       = errors.New("team has open PRs")
 )
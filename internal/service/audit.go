@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	defaultAuditPageLimit = 50
+	maxAuditPageLimit     = 100
+)
+
+// ListAuditEvents returns a page of audit events, newest first. The limit
+// defaults to 50 and is capped at 100.
+func (s *Service) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	const op = "internal.service.audit.ListAuditEvents"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	if err := validateAuditFilter(filter); err != nil {
+		log.Warn("invalid audit filter")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditPageLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	log.Info("attempting to list audit events")
+	events, err := s.AuditProvider.ListAuditEvents(ctx, filter)
+	if err != nil {
+		log.Error("failed to list audit events", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully listed audit events", slog.Int("count", len(events)))
+	return events, nil
+}
+
+func validateAuditFilter(filter domain.AuditFilter) error {
+	switch filter.EntityType {
+	case "", domain.AuditEntityPR, domain.AuditEntityUser, domain.AuditEntityTeam,
+		domain.AuditEntitySubscription, domain.AuditEntityToken:
+	default:
+		return ErrInvalidAuditFilter
+	}
+	if filter.EntityID != "" && filter.EntityType == "" {
+		return ErrInvalidAuditFilter
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return ErrInvalidAuditFilter
+	}
+
+	return nil
+}
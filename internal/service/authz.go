@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// actorID returns the ID of the user making the request, or empty for
+// machine callers and background work.
+func actorID(ctx context.Context) string {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return principal.UserID
+}
+
+// caller returns the user making the request. Machine callers and
+// background work have no user: API tokens are governed by their scopes
+// alone and pass every role check.
+func (s *Service) caller(ctx context.Context) (*domain.User, bool, error) {
+	const op = "internal.service.authz.caller"
+
+	userId := actorID(ctx)
+	if userId == "" {
+		return nil, false, nil
+	}
+
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, false, fmt.Errorf("%s: %w", op, ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, true, nil
+}
+
+// authorizeLead allows admins and team leads of any of the teams.
+func (s *Service) authorizeLead(ctx context.Context, teamIds ...int) error {
+	const op = "internal.service.authz.authorizeLead"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ok && !user.Leads(teamIds...) {
+		return fmt.Errorf("%s: %w: %s is not a lead of the team", op, ErrForbidden, user.ID)
+	}
+
+	return nil
+}
+
+// authorizeAdmin allows admins only.
+func (s *Service) authorizeAdmin(ctx context.Context) error {
+	const op = "internal.service.authz.authorizeAdmin"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ok && user.Role != domain.RoleAdmin {
+		return fmt.Errorf("%s: %w: %s is not an admin", op, ErrForbidden, user.ID)
+	}
+
+	return nil
+}
+
+// authorizeTeam allows admins and leads of the named team.
+func (s *Service) authorizeTeam(ctx context.Context, teamName string) error {
+	const op = "internal.service.authz.authorizeTeam"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok || user.Role == domain.RoleAdmin {
+		return nil
+	}
+
+	team, err := s.TeamProvider.GetTeam(ctx, teamName)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !user.Leads(team.ID) {
+		return fmt.Errorf("%s: %w: %s is not a lead of %s", op, ErrForbidden, user.ID, teamName)
+	}
+
+	return nil
+}
+
+// authorizeTeammate allows users to change themselves, and admins and leads
+// of any of the user's teams to change them.
+func (s *Service) authorizeTeammate(ctx context.Context, userId string) error {
+	const op = "internal.service.authz.authorizeTeammate"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok || user.ID == userId || user.Role == domain.RoleAdmin {
+		return nil
+	}
+
+	teammate, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	teamIds := make([]int, len(teammate.Teams))
+	for i, membership := range teammate.Teams {
+		teamIds[i] = membership.TeamID
+	}
+	if !user.Leads(teamIds...) {
+		return fmt.Errorf("%s: %w: %s does not lead a team of %s", op, ErrForbidden, user.ID, userId)
+	}
+
+	return nil
+}
+
+// authorizeReassign lets reviewers reassign only themselves away. Admins and
+// leads of the PR's team may reassign anyone.
+func (s *Service) authorizeReassign(ctx context.Context, prId string, reviewerId string) error {
+	const op = "internal.service.authz.authorizeReassign"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !ok || user.ID == reviewerId || user.Role == domain.RoleAdmin {
+		return nil
+	}
+
+	pr, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !user.Leads(pr.TeamID) {
+		return fmt.Errorf("%s: %w: %s may only reassign themselves", op, ErrForbidden, user.ID)
+	}
+
+	return nil
+}
+
+// authorizeSelf lets users act only as themselves.
+func (s *Service) authorizeSelf(ctx context.Context, userId string) error {
+	const op = "internal.service.authz.authorizeSelf"
+
+	user, ok, err := s.caller(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ok && user.ID != userId {
+		return fmt.Errorf("%s: %w: %s may not act as %s", op, ErrForbidden, user.ID, userId)
+	}
+
+	return nil
+}
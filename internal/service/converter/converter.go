@@ -28,7 +28,7 @@ func ToUserDtoFromService(user *serv.User) request2.UserResponse {
 	return request2.UserResponse{
 		UserID:   user.ID,
 		Username: user.Username,
-		TeamName: user.TeamName,
+		Teams:    user.TeamNames(),
 		IsActive: user.IsActive,
 	}
 }
@@ -49,7 +49,7 @@ func ToTeamDtoFromService(team *serv.Team) request2.TeamResponse {
 	return teamDto
 }
 
-func ToMemberFromDto(memberDTO request.TeamMemberRequest, teamId string) serv.Member {
+func ToMemberFromDto(memberDTO request.TeamMemberRequest, teamId int) serv.Member {
 	return serv.Member{
 		UserID:   memberDTO.UserID,
 		Username: memberDTO.Username,
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const digestTimeout = 30 * time.Second
+
+// SendDigests emails the daily digest to every recipient whose working day
+// has started since they were last sent one, and reports how many were
+// sent. Recipients who opted out are skipped, and those without open
+// reviews are skipped for the day. A failed digest is retried on the next
+// run.
+func (s *Service) SendDigests(ctx context.Context) (int, error) {
+	const op = "internal.service.digest.SendDigests"
+
+	if s.mailer == nil {
+		return 0, nil
+	}
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	recipients, err := s.UserProvider.GetDigestRecipients(ctx)
+	if err != nil {
+		log.Error("failed to get digest recipients", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := s.now()
+	sent := 0
+	for _, recipient := range recipients {
+		if !recipient.DigestEnabled {
+			continue
+		}
+
+		start, ok := recipient.WorkingHours.DayStart(now)
+		if !ok || now.Before(start) || (recipient.SentAt != nil && !recipient.SentAt.Before(start)) {
+			continue
+		}
+
+		log := log.With(slog.String("userId", recipient.UserID))
+
+		digest, err := s.digest(ctx, recipient, now)
+		if err != nil {
+			log.Error("failed to build digest", sl.Err(err))
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(digest.Reviews) > 0 {
+			if err := s.sendDigest(ctx, digest); err != nil {
+				log.Warn("failed to send digest", sl.Err(err))
+				continue
+			}
+			sent++
+		}
+
+		if err := s.UserProvider.MarkDigestSent(ctx, recipient.UserID); err != nil {
+			log.Error("failed to mark digest sent", sl.Err(err))
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if sent > 0 {
+		log.Info("sent digests", slog.Int("count", sent))
+	}
+	return sent, nil
+}
+
+// SendDigest emails the user their digest right away, even when they have
+// no open reviews or have opted out. It does not affect the daily schedule.
+func (s *Service) SendDigest(ctx context.Context, userId string) (*domain.Digest, error) {
+	const op = "internal.service.digest.SendDigest"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId))
+
+	if err := s.authorizeTeammate(ctx, userId); err != nil {
+		log.Warn("caller may not send the user's digest", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.mailer == nil {
+		log.Warn("email digest is not configured")
+		return nil, fmt.Errorf("%s: %w", op, ErrDigestDisabled)
+	}
+
+	log.Info("attempting to get user")
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.Email == "" {
+		log.Warn("user has no email")
+		return nil, fmt.Errorf("%s: %w", op, ErrNoEmail)
+	}
+
+	log.Info("attempting to send digest")
+	digest, err := s.digest(ctx, &domain.DigestRecipient{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		WorkingHours: user.WorkingHours,
+	}, s.now())
+	if err != nil {
+		log.Error("failed to build digest", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.sendDigest(ctx, digest)
+	if err != nil {
+		log.Error("failed to send digest", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully sent digest")
+	return &digest, nil
+}
+
+// digest collects the recipient's open reviews, oldest first.
+func (s *Service) digest(ctx context.Context, recipient *domain.DigestRecipient, now time.Time) (domain.Digest, error) {
+	const op = "internal.service.digest.digest"
+
+	prs, err := s.reviewsWithWait(ctx, recipient.UserID, recipient.WorkingHours, now)
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviews := make([]*domain.PRShort, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status == "OPEN" {
+			reviews = append(reviews, pr)
+		}
+	}
+
+	// Reviews from before assignment times were recorded count as the oldest.
+	assignedAt := func(pr *domain.PRShort) time.Time {
+		if pr.AssignedAt == nil {
+			return time.Time{}
+		}
+		return *pr.AssignedAt
+	}
+	slices.SortStableFunc(reviews, func(a, b *domain.PRShort) int {
+		return assignedAt(a).Compare(assignedAt(b))
+	})
+
+	return domain.Digest{
+		GeneratedAt: now,
+		UserID:      recipient.UserID,
+		Username:    recipient.Username,
+		Email:       recipient.Email,
+		Reviews:     reviews,
+	}, nil
+}
+
+func (s *Service) sendDigest(ctx context.Context, digest domain.Digest) error {
+	ctx, cancel := context.WithTimeout(ctx, digestTimeout)
+	defer cancel()
+
+	return s.mailer.SendDigest(ctx, digest)
+}
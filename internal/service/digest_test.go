@@ -0,0 +1,364 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/client/email"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// smtpSink is an SMTP server that accepts every message and keeps it.
+type smtpSink struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []sinkMessage
+}
+
+type sinkMessage struct {
+	to      []string
+	subject string
+	text    string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sink := &smtpSink{listener: listener}
+	go sink.serve(t)
+
+	return sink
+}
+
+func (s *smtpSink) serve(t *testing.T) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(t, conn)
+	}
+}
+
+func (s *smtpSink) handle(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 sink ready")
+
+	var to []string
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO", "MAIL", "NOOP", "RSET":
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			_, address, _ := strings.Cut(arg, "<")
+			to = append(to, strings.TrimSuffix(address, ">"))
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 end with <CRLF>.<CRLF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			message, err := parseDigestMessage(to, data)
+			if err != nil {
+				t.Errorf("parse message: %v", err)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			to = nil
+			text.PrintfLine("250 queued")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (s *smtpSink) received() []sinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]sinkMessage(nil), s.messages...)
+}
+
+// parseDigestMessage reads the subject and the text part of a digest.
+func parseDigestMessage(to []string, data []byte) (sinkMessage, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return sinkMessage{}, err
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		return sinkMessage{}, err
+	}
+	_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		return sinkMessage{}, err
+	}
+
+	part, err := multipart.NewReader(message.Body, params["boundary"]).NextPart()
+	if err != nil {
+		return sinkMessage{}, err
+	}
+	text, err := io.ReadAll(part)
+	if err != nil {
+		return sinkMessage{}, err
+	}
+
+	return sinkMessage{to: to, subject: subject, text: string(text)}, nil
+}
+
+type digestUsers struct {
+	UserProvider
+	users      map[string]*domain.User
+	recipients []domain.DigestRecipient
+	reviews    map[string][]domain.PRShort
+	now        func() time.Time
+}
+
+func (f *digestUsers) GetUser(_ context.Context, userId string) (*domain.User, error) {
+	user, ok := f.users[userId]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (f *digestUsers) GetDigestRecipients(_ context.Context) ([]*domain.DigestRecipient, error) {
+	recipients := make([]*domain.DigestRecipient, len(f.recipients))
+	for i := range f.recipients {
+		recipient := f.recipients[i]
+		recipients[i] = &recipient
+	}
+
+	return recipients, nil
+}
+
+func (f *digestUsers) GetReview(_ context.Context, reviewerId string) ([]*domain.PRShort, error) {
+	reviews := make([]*domain.PRShort, len(f.reviews[reviewerId]))
+	for i := range f.reviews[reviewerId] {
+		review := f.reviews[reviewerId][i]
+		reviews[i] = &review
+	}
+
+	return reviews, nil
+}
+
+func (f *digestUsers) MarkDigestSent(_ context.Context, userId string) error {
+	for i := range f.recipients {
+		if f.recipients[i].UserID == userId {
+			now := f.now()
+			f.recipients[i].SentAt = &now
+		}
+	}
+
+	return nil
+}
+
+type digestConfig struct{}
+
+func (digestConfig) PairingWindow() time.Duration {
+	return 0
+}
+
+func (digestConfig) ReviewSLA() time.Duration {
+	return 8 * time.Hour
+}
+
+func newDigestService(t *testing.T, users *digestUsers, now *time.Time) (*Service, *smtpSink) {
+	t.Helper()
+
+	sink := newSMTPSink(t)
+	_, port, _ := net.SplitHostPort(sink.listener.Addr().String())
+	t.Setenv("SMTP_HOST", "127.0.0.1")
+	t.Setenv("SMTP_PORT", port)
+	t.Setenv("SMTP_FROM", "PR Reviewer <reviews@example.com>")
+	smtpConfig, err := config.NewSMTPConfig()
+	if err != nil {
+		t.Fatalf("NewSMTPConfig: %v", err)
+	}
+
+	users.now = func() time.Time { return *now }
+	s := New(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Providers{User: users},
+		Clients{Mailer: email.NewMailer(smtpConfig)},
+		digestConfig{},
+	)
+	s.now = users.now
+
+	return s, sink
+}
+
+func at(year int, month time.Month, day int, hour int, minute int) *time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	return &t
+}
+
+func TestSendDigests(t *testing.T) {
+	office := domain.WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18}
+	users := &digestUsers{
+		recipients: []domain.DigestRecipient{
+			{UserID: "u1", Username: "alice", Email: "alice@example.com", WorkingHours: office, DigestEnabled: true},
+			{UserID: "u2", Username: "bob", Email: "bob@example.com", WorkingHours: office},
+			{UserID: "u3", Username: "carol", Email: "carol@example.com", WorkingHours: office, DigestEnabled: true},
+		},
+		reviews: map[string][]domain.PRShort{
+			"u1": {
+				{ID: "pr-2", Name: "Add metrics", AuthorID: "u4", Status: "OPEN", AssignedAt: at(2026, 3, 4, 9, 15)},
+				{ID: "pr-1", Name: "Fix login", AuthorID: "u4", Status: "OPEN", AssignedAt: at(2026, 2, 27, 10, 0)},
+				{ID: "pr-4", Name: "Bump deps", AuthorID: "u4", Status: "MERGED", AssignedAt: at(2026, 2, 20, 10, 0)},
+				{ID: "pr-3", Name: "Update docs", AuthorID: "u5", Status: "OPEN", AssignedAt: at(2026, 3, 3, 15, 0)},
+			},
+			"u2": {
+				{ID: "pr-1", Name: "Fix login", AuthorID: "u4", Status: "OPEN", AssignedAt: at(2026, 2, 27, 10, 0)},
+			},
+		},
+	}
+	// A Wednesday morning.
+	now := at(2026, 3, 4, 10, 0)
+	s, sink := newDigestService(t, users, now)
+
+	sent, err := s.SendDigests(context.Background())
+	if err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+	if sent != 1 {
+		t.Fatalf("sent %d digests, want 1", sent)
+	}
+
+	messages := sink.received()
+	if len(messages) != 1 {
+		t.Fatalf("received %d messages, want 1", len(messages))
+	}
+	message := messages[0]
+	if len(message.to) != 1 || message.to[0] != "alice@example.com" {
+		t.Errorf("sent to %v, want alice@example.com", message.to)
+	}
+	if want := "Your reviews: 3 pending, 1 overdue"; message.subject != want {
+		t.Errorf("subject = %q, want %q", message.subject, want)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(message.text, "\n") {
+		if strings.Contains(line, "(pr-") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	wantLines := []string{
+		"[OVERDUE] Fix login (pr-1) by u4, waiting 27h0m",
+		"Update docs (pr-3) by u5, waiting 4h0m",
+		"Add metrics (pr-2) by u4, waiting 45m",
+	}
+	if strings.Join(lines, "\n") != strings.Join(wantLines, "\n") {
+		t.Errorf("reviews =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(wantLines, "\n"))
+	}
+
+	for _, recipient := range users.recipients {
+		wantSent := recipient.UserID != "u2"
+		if sentAt := recipient.SentAt; (sentAt != nil) != wantSent {
+			t.Errorf("%s marked sent at %v, want marked = %t", recipient.UserID, sentAt, wantSent)
+		}
+	}
+
+	// Later the same day nobody gets a second digest.
+	*now = *at(2026, 3, 4, 16, 0)
+	sent, err = s.SendDigests(context.Background())
+	if err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+	if sent != 0 || len(sink.received()) != 1 {
+		t.Errorf("sent %d digests later the same day, want none", sent)
+	}
+
+	// The next working day starts a new digest.
+	*now = *at(2026, 3, 5, 9, 30)
+	sent, err = s.SendDigests(context.Background())
+	if err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+	if sent != 1 || len(sink.received()) != 2 {
+		t.Errorf("sent %d digests the next day, want 1", sent)
+	}
+}
+
+func TestSendDigestAuthorizesTeammates(t *testing.T) {
+	platform := []domain.TeamMembership{{TeamID: 1, TeamName: "platform", Role: domain.RoleMember}}
+	users := &digestUsers{
+		users: map[string]*domain.User{
+			"u1": {ID: "u1", Email: "alice@example.com", IsActive: true, Teams: platform},
+			"u2": {ID: "u2", Email: "bob@example.com", IsActive: true, Teams: platform},
+			"u3": {
+				ID:       "u3",
+				IsActive: true,
+				Teams:    []domain.TeamMembership{{TeamID: 1, TeamName: "platform", Role: domain.RoleTeamLead}},
+			},
+		},
+	}
+	s, sink := newDigestService(t, users, at(2026, 3, 4, 10, 0))
+
+	tests := []struct {
+		name    string
+		caller  string
+		userId  string
+		wantErr error
+	}{
+		{name: "self", caller: "u1", userId: "u1"},
+		{name: "team lead", caller: "u3", userId: "u1"},
+		{name: "teammate", caller: "u2", userId: "u1", wantErr: ErrForbidden},
+		{name: "unknown caller", caller: "u9", userId: "u1", wantErr: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(sink.received())
+			ctx := auth.WithPrincipal(context.Background(), &domain.Principal{UserID: tt.caller})
+
+			_, err := s.SendDigest(ctx, tt.userId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			wantSent := 1
+			if tt.wantErr != nil {
+				wantSent = 0
+			}
+			if got := len(sink.received()) - before; got != wantSent {
+				t.Errorf("sent %d digests, want %d", got, wantSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+const (
+	AssignmentTriggerCreate   = "create"
+	AssignmentTriggerReassign = "reassign"
+)
+
+const (
+	AssignmentStrategyUniform      = "uniform"
+	AssignmentStrategyAntiAffinity = "anti_affinity"
+)
+
+const (
+	ExclusionReasonAuthor          = "author"
+	ExclusionReasonInactive        = "inactive"
+	ExclusionReasonAlreadyAssigned = "already_assigned"
+	ExclusionReasonReplaced        = "replaced"
+)
+
+// AssignmentDecision records why a set of reviewers was chosen. Replaying the
+// weighted draw over Scores with Seed yields Chosen again.
+type AssignmentDecision struct {
+	CreatedAt *time.Time
+	PRID      string
+	Trigger   string
+	Strategy  string
+	// ActorID is the user whose request triggered the decision, if any.
+	ActorID    string
+	Candidates []string
+	Exclusions []AssignmentExclusion
+	Scores     []AssignmentScore
+	Chosen     []string
+	Seed       int64
+	TeamID     int
+}
+
+type AssignmentExclusion struct {
+	UserID string
+	Reason string
+}
+
+type AssignmentScore struct {
+	UserID         string
+	Pairings       int
+	Weight         float64
+	InWorkingHours bool
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// AuditEntity is the kind of entity an audit event is about.
+type AuditEntity string
+
+const (
+	AuditEntityPR           AuditEntity = "pr"
+	AuditEntityUser         AuditEntity = "user"
+	AuditEntityTeam         AuditEntity = "team"
+	AuditEntitySubscription AuditEntity = "subscription"
+	AuditEntityToken        AuditEntity = "token"
+)
+
+const (
+	AuditActionPRCreate           = "pr.create"
+	AuditActionPRMerge            = "pr.merge"
+	AuditActionPRClose            = "pr.close"
+	AuditActionPRReopen           = "pr.reopen"
+	AuditActionPRReassign         = "pr.reassign"
+	AuditActionPRUnassign         = "pr.unassign"
+	AuditActionPRReview           = "pr.review"
+	AuditActionUserSetActive      = "user.set_active"
+	AuditActionUserSetWorkHours   = "user.set_working_hours"
+	AuditActionUserSetEmail       = "user.set_email"
+	AuditActionUserSetRole        = "user.set_role"
+	AuditActionUserSetTeamRole    = "user.set_team_role"
+	AuditActionUserMoveTeam       = "user.move_team"
+	AuditActionTeamCreate         = "team.create"
+	AuditActionTeamUpdate         = "team.update"
+	AuditActionTeamDelete         = "team.delete"
+	AuditActionSubscriptionCreate = "subscription.create"
+	AuditActionSubscriptionDelete = "subscription.delete"
+	AuditActionTokenCreate        = "token.create"
+	AuditActionTokenRevoke        = "token.revoke"
+)
+
+// AuditActorSystem is the actor of changes made without a caller, by VCS
+// webhooks and background workers.
+const AuditActorSystem = "system"
+
+// AuditEvent records who changed an entity and how. Diff holds the fields
+// that changed; Before is nil for created entities and After for deleted
+// ones.
+type AuditEvent struct {
+	CreatedAt  time.Time
+	Actor      string
+	Action     string
+	EntityType AuditEntity
+	EntityID   string
+	RequestID  string
+	Diff       map[string]AuditChange
+	ID         int64
+}
+
+type AuditChange struct {
+	Before any
+	After  any
+}
+
+// AuditFilter selects audit events, newest first. Zero values do not
+// filter; From is inclusive and To exclusive.
+type AuditFilter struct {
+	From       *time.Time
+	To         *time.Time
+	EntityType AuditEntity
+	EntityID   string
+	Actor      string
+	Limit      int
+	Offset     int
+}
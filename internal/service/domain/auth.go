@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Scope is a level of API access. Each scope grants the ones below it:
+// admin grants write and write grants read.
+type Scope string
+
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes lists the scopes from the least to the most privileged.
+var Scopes = []Scope{ScopeRead, ScopeWrite, ScopeAdmin}
+
+func (s Scope) Valid() bool {
+	return slices.Contains(Scopes, s)
+}
+
+// Grants reports whether holding the scope grants the required one.
+func (s Scope) Grants(required Scope) bool {
+	held := slices.Index(Scopes, s)
+	return held >= 0 && held >= slices.Index(Scopes, required)
+}
+
+// APIToken is a machine token. Only its hash is stored; Token holds the
+// plain token right after creation and is empty otherwise.
+type APIToken struct {
+	CreatedAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	Name       string
+	Prefix     string
+	Token      string
+	Scopes     []Scope
+	ID         int64
+}
+
+// Role is what a user may do. Admin and member are roles of the user; team
+// lead is a role in one team, held by members of that team.
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team_lead"
+	RoleMember   Role = "member"
+)
+
+// Principal is the authenticated caller of a request: a machine holding an
+// API token, or a person signed in through OIDC, identified by UserID.
+type Principal struct {
+	Name    string
+	UserID  string
+	Scopes  []Scope
+	TokenID int64
+}
+
+// Actor identifies the principal in the audit log: the user ID of people and
+// token:<id> for API tokens.
+func (p *Principal) Actor() string {
+	if p.UserID != "" {
+		return p.UserID
+	}
+
+	return "token:" + strconv.FormatInt(p.TokenID, 10)
+}
+
+// HasScope reports whether any of the principal's scopes grants the
+// required one.
+func (p *Principal) HasScope(required Scope) bool {
+	return slices.ContainsFunc(p.Scopes, func(scope Scope) bool {
+		return scope.Grants(required)
+	})
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// DigestRecipient is an active user with an email who may receive the daily
+// digest. SentAt is when they were last sent one, and DigestEnabled is false
+// once they opted out.
+type DigestRecipient struct {
+	SentAt        *time.Time
+	UserID        string
+	Username      string
+	Email         string
+	WorkingHours  WorkingHours
+	DigestEnabled bool
+}
+
+// Digest lists a user's open reviews, oldest first.
+type Digest struct {
+	GeneratedAt time.Time
+	UserID      string
+	Username    string
+	Email       string
+	Reviews     []*PRShort
+}
+
+// OverdueCount returns how many of the reviews are overdue.
+func (d Digest) OverdueCount() int {
+	count := 0
+	for _, review := range d.Reviews {
+		if review.Overdue {
+			count++
+		}
+	}
+
+	return count
+}
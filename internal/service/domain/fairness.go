@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// FairnessFilter narrows the report to a team; an empty TeamName covers every
+// team.
+type FairnessFilter struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+}
+
+type FairnessReport struct {
+	From  time.Time
+	To    time.Time
+	Teams []TeamFairness
+}
+
+// TeamFairness describes how evenly a team's PRs were spread over its
+// reviewers. MaxMinRatio is nil when some reviewer got no assignments.
+type TeamFairness struct {
+	TeamName    string
+	Reviewers   []ReviewerFairness
+	Mean        float64
+	Gini        float64
+	MaxMinRatio *float64
+	TeamID      int
+}
+
+// ReviewerFairness holds a reviewer's load and its deviation from the team
+// mean.
+type ReviewerFairness struct {
+	UserID      string
+	Username    string
+	Assignments int
+	Deviation   float64
+}
+
+// NewTeamFairness computes the load distribution of the team's reviewers from
+// their assignment counts.
+func NewTeamFairness(teamId int, teamName string, stats []UserAssignmentStat) TeamFairness {
+	fairness := TeamFairness{
+		TeamID:    teamId,
+		TeamName:  teamName,
+		Reviewers: make([]ReviewerFairness, len(stats)),
+	}
+	if len(stats) == 0 {
+		return fairness
+	}
+
+	loads := make([]int, len(stats))
+	total := 0
+	for i, stat := range stats {
+		loads[i] = stat.TotalAssignments
+		total += stat.TotalAssignments
+	}
+	fairness.Mean = float64(total) / float64(len(stats))
+
+	for i, stat := range stats {
+		fairness.Reviewers[i] = ReviewerFairness{
+			UserID:      stat.UserID,
+			Username:    stat.Username,
+			Assignments: stat.TotalAssignments,
+			Deviation:   float64(stat.TotalAssignments) - fairness.Mean,
+		}
+	}
+
+	fairness.Gini = Gini(loads)
+
+	if minLoad := slices.Min(loads); minLoad > 0 {
+		ratio := float64(slices.Max(loads)) / float64(minLoad)
+		fairness.MaxMinRatio = &ratio
+	}
+
+	return fairness
+}
+
+// Gini is the Gini coefficient of the loads: zero when they are equal, close
+// to one when a single reviewer takes them all. It is zero without load.
+func Gini(loads []int) float64 {
+	sorted := slices.Clone(loads)
+	slices.Sort(sorted)
+
+	total, weighted := 0, 0
+	for i, load := range sorted {
+		total += load
+		weighted += (i + 1) * load
+	}
+	if total == 0 {
+		return 0
+	}
+
+	n := float64(len(sorted))
+	return 2*float64(weighted)/(n*float64(total)) - (n+1)/n
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name  string
+		loads []int
+		want  float64
+	}{
+		{name: "no reviewers", loads: nil, want: 0},
+		{name: "equal load", loads: []int{4, 4, 4, 4}, want: 0},
+		{name: "all zero", loads: []int{0, 0, 0}, want: 0},
+		{name: "one reviewer takes everything", loads: []int{0, 9, 0, 0}, want: 3.0 / 4},
+		{name: "one of two takes everything", loads: []int{5, 0}, want: 1.0 / 2},
+		{name: "uneven", loads: []int{1, 2, 3}, want: 2.0 / 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Gini(tt.loads); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Gini(%v) = %v, want %v", tt.loads, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTeamFairness(t *testing.T) {
+	stats := func(loads ...int) []UserAssignmentStat {
+		stats := make([]UserAssignmentStat, len(loads))
+		for i, load := range loads {
+			id := string(rune('a' + i))
+			stats[i] = UserAssignmentStat{UserID: id, Username: id, TotalAssignments: load}
+		}
+		return stats
+	}
+	ratio := func(r float64) *float64 { return &r }
+
+	tests := []struct {
+		name           string
+		stats          []UserAssignmentStat
+		wantMean       float64
+		wantGini       float64
+		wantRatio      *float64
+		wantDeviations []float64
+	}{
+		{
+			name:           "equal load",
+			stats:          stats(3, 3, 3),
+			wantMean:       3,
+			wantGini:       0,
+			wantRatio:      ratio(1),
+			wantDeviations: []float64{0, 0, 0},
+		},
+		{
+			name:           "one reviewer takes everything",
+			stats:          stats(6, 0, 0),
+			wantMean:       2,
+			wantGini:       2.0 / 3,
+			wantDeviations: []float64{4, -2, -2},
+		},
+		{
+			name:           "all zero",
+			stats:          stats(0, 0),
+			wantDeviations: []float64{0, 0},
+		},
+		{
+			name:           "a reviewer without assignments",
+			stats:          stats(2, 4, 0),
+			wantMean:       2,
+			wantGini:       4.0 / 9,
+			wantDeviations: []float64{0, 2, -2},
+		},
+		{
+			name:           "uneven",
+			stats:          stats(1, 3),
+			wantMean:       2,
+			wantGini:       1.0 / 4,
+			wantRatio:      ratio(3),
+			wantDeviations: []float64{-1, 1},
+		},
+		{name: "no reviewers", stats: nil, wantDeviations: []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTeamFairness(1, "platform", tt.stats)
+
+			if got.TeamID != 1 || got.TeamName != "platform" {
+				t.Errorf("team = %d %q", got.TeamID, got.TeamName)
+			}
+			if math.Abs(got.Mean-tt.wantMean) > epsilon {
+				t.Errorf("mean = %v, want %v", got.Mean, tt.wantMean)
+			}
+			if math.Abs(got.Gini-tt.wantGini) > epsilon {
+				t.Errorf("gini = %v, want %v", got.Gini, tt.wantGini)
+			}
+			switch {
+			case tt.wantRatio == nil && got.MaxMinRatio != nil:
+				t.Errorf("max/min ratio = %v, want nil", *got.MaxMinRatio)
+			case tt.wantRatio != nil && got.MaxMinRatio == nil:
+				t.Errorf("max/min ratio = nil, want %v", *tt.wantRatio)
+			case tt.wantRatio != nil && math.Abs(*got.MaxMinRatio-*tt.wantRatio) > epsilon:
+				t.Errorf("max/min ratio = %v, want %v", *got.MaxMinRatio, *tt.wantRatio)
+			}
+
+			deviations := make([]float64, len(got.Reviewers))
+			for i, reviewer := range got.Reviewers {
+				deviations[i] = reviewer.Deviation
+				if reviewer.UserID != tt.stats[i].UserID || reviewer.Assignments != tt.stats[i].TotalAssignments {
+					t.Errorf("reviewer %d = %+v, want %+v", i, reviewer, tt.stats[i])
+				}
+			}
+			if !slices.Equal(deviations, tt.wantDeviations) {
+				t.Errorf("deviations = %v, want %v", deviations, tt.wantDeviations)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+type LatencyFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// Latency summarizes a set of durations.
+type Latency struct {
+	Count  int
+	Median time.Duration
+	P90    time.Duration
+}
+
+// LatencyMetrics covers PRs merged, first reviews given and reviewers
+// assigned within the period.
+type LatencyMetrics struct {
+	TimeToMerge       Latency
+	TimeToFirstReview Latency
+	Assignments       int
+	// Reassignments counts assignments from the period that were later
+	// handed to another reviewer.
+	Reassignments int
+}
+
+func (m LatencyMetrics) ReassignmentRate() float64 {
+	return ReassignmentRate(m.Assignments, m.Reassignments)
+}
+
+// TeamLatency holds the metrics of a team's PRs. TeamID is zero for PRs
+// without a team.
+type TeamLatency struct {
+	LatencyMetrics
+	TeamName string
+	TeamID   int
+}
+
+// ReviewerLatency measures the time from a reviewer's assignment to their
+// first review of the PR.
+type ReviewerLatency struct {
+	TimeToFirstReview Latency
+	UserID            string
+	Username          string
+	Assignments       int
+	Reassignments     int
+}
+
+func (l ReviewerLatency) ReassignmentRate() float64 {
+	return ReassignmentRate(l.Assignments, l.Reassignments)
+}
+
+type LatencyStatistics struct {
+	From      time.Time
+	To        time.Time
+	Overall   LatencyMetrics
+	Teams     []TeamLatency
+	Reviewers []ReviewerLatency
+}
+
+// ReassignmentRate is the share of assignments that were reassigned, zero
+// without assignments.
+func ReassignmentRate(assignments, reassignments int) float64 {
+	if assignments == 0 {
+		return 0
+	}
+
+	return float64(reassignments) / float64(assignments)
+}
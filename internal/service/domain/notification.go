@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type NotificationKind string
+
+const (
+	NotificationAssigned   NotificationKind = "assigned"
+	NotificationReassigned NotificationKind = "reassigned"
+	NotificationOverdue    NotificationKind = "overdue"
+)
+
+// Notification tells a reviewer about a review of theirs. ReplacedUserID is
+// only set for reassignments and Wait, the business time the review has
+// waited, for overdue reviews.
+type Notification struct {
+	Kind           NotificationKind
+	PRID           string
+	PRName         string
+	TeamName       string
+	UserID         string
+	ReplacedUserID string
+	Wait           time.Duration
+}
+
+// QueuedNotification is a notification waiting to be sent. FailedAt is set
+// once it is given up.
+type QueuedNotification struct {
+	Notification
+	NextAttemptAt time.Time
+	FailedAt      *time.Time
+	LastError     string
+	ID            int64
+	Attempts      int
+}
+
+// NotificationAttempt records a failed attempt: the notification is retried
+// after RetryIn unless GiveUp is set.
+type NotificationAttempt struct {
+	Error   string
+	RetryIn time.Duration
+	GiveUp  bool
+}
+
+// OpenReview is an active reviewer's review of an open PR that has not been
+// reported overdue yet.
+type OpenReview struct {
+	AssignedAt time.Time
+	PRID       string
+	PRName     string
+	TeamName   string
+	UserID     string
+	TeamID     int
+}
@@ -7,20 +7,38 @@ type PRStatus int
 const (
 	PRStatusOpen PRStatus = iota
 	PRStatusMerged
+	PRStatusClosed
 )
 
 type PR struct {
 	CreatedAt *time.Time
 	MergedAt  *time.Time
+	ClosedAt  *time.Time
+	Source    *PRSource
 	ID        string
 	Name      string
 	AuthorID  string
+	// MergedBy is the user who merged the PR through the API, empty when it
+	// was merged by a machine caller or a VCS webhook.
+	MergedBy  string
 	Reviewers []string
+	TeamID    int
 	Status    PRStatus
 }
+
+// PRSource locates a PR registered from a VCS webhook on its code host.
+type PRSource struct {
+	Provider   string
+	Repository string
+	Number     int
+}
 type PRShort struct {
-	ID       string
-	Name     string
-	AuthorID string
-	Status   string
+	AssignedAt   *time.Time
+	ID           string
+	Name         string
+	AuthorID     string
+	Status       string
+	TeamID       int
+	BusinessWait time.Duration
+	Overdue      bool
 }
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+type PREventType string
+
+const (
+	PREventCreated          PREventType = "created"
+	PREventReviewerAssigned PREventType = "reviewer_assigned"
+	PREventReviewerRemoved  PREventType = "reviewer_removed"
+	PREventReviewed         PREventType = "reviewed"
+	PREventMerged           PREventType = "merged"
+	PREventClosed           PREventType = "closed"
+	PREventReopened         PREventType = "reopened"
+)
+
+// Reasons a reviewer was removed from a PR.
+const (
+	UnassignReasonReassigned = "reassigned"
+	UnassignReasonMovedTeam  = "moved_team"
+	UnassignReasonLeftTeam   = "left_team"
+)
+
+type ReviewDecision string
+
+const (
+	ReviewApproved         ReviewDecision = "approved"
+	ReviewChangesRequested ReviewDecision = "changes_requested"
+	ReviewCommented        ReviewDecision = "commented"
+)
+
+// PREvent is an entry in a PR's timeline. UserID is the reviewer the event
+// is about. Reason explains reviewer changes: the assignment trigger for
+// assignments, an UnassignReason for removals.
+type PREvent struct {
+	CreatedAt time.Time
+	Type      PREventType
+	Actor     string
+	UserID    string
+	Reason    string
+	Decision  ReviewDecision
+	ID        int64
+}
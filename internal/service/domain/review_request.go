@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ReviewRequest asks the code host to request reviews from Reviewers and to
+// withdraw the requests of Removed. Both hold user IDs.
+type ReviewRequest struct {
+	PRID      string
+	Source    PRSource
+	Reviewers []string
+	Removed   []string
+}
+
+// Coalesce folds an older request for the same PR into this one. A user this
+// request adds or removes keeps that change; the older request only
+// contributes users this one does not mention.
+func (r ReviewRequest) Coalesce(older ReviewRequest) ReviewRequest {
+	mentioned := func(userId string) bool {
+		return slices.Contains(r.Reviewers, userId) || slices.Contains(r.Removed, userId)
+	}
+
+	coalesced := r
+	coalesced.Reviewers = slices.Clone(r.Reviewers)
+	coalesced.Removed = slices.Clone(r.Removed)
+	for _, userId := range older.Reviewers {
+		if !mentioned(userId) && !slices.Contains(coalesced.Reviewers, userId) {
+			coalesced.Reviewers = append(coalesced.Reviewers, userId)
+		}
+	}
+	for _, userId := range older.Removed {
+		if !mentioned(userId) && !slices.Contains(coalesced.Removed, userId) {
+			coalesced.Removed = append(coalesced.Removed, userId)
+		}
+	}
+
+	return coalesced
+}
+
+// Narrow drops the changes that no longer apply to a PR with the given
+// current reviewers: users unassigned since are not requested, and users
+// assigned again are not withdrawn.
+func (r ReviewRequest) Narrow(current []string) ReviewRequest {
+	narrowed := r
+	narrowed.Reviewers = slices.DeleteFunc(slices.Clone(r.Reviewers), func(userId string) bool {
+		return !slices.Contains(current, userId)
+	})
+	narrowed.Removed = slices.DeleteFunc(slices.Clone(r.Removed), func(userId string) bool {
+		return slices.Contains(current, userId)
+	})
+
+	return narrowed
+}
+
+// Empty reports whether the request changes nothing.
+func (r ReviewRequest) Empty() bool {
+	return len(r.Reviewers)+len(r.Removed) == 0
+}
+
+// QueuedReviewRequest is a review request that failed and waits for a
+// retry. FailedAt is set once it is given up.
+type QueuedReviewRequest struct {
+	ReviewRequest
+	NextAttemptAt time.Time
+	FailedAt      *time.Time
+	LastError     string
+	ID            int64
+	Attempts      int
+}
+
+// ReviewRequestAttempt records a failed attempt: the request is retried
+// after RetryIn unless GiveUp is set.
+type ReviewRequestAttempt struct {
+	Error   string
+	RetryIn time.Duration
+	GiveUp  bool
+}
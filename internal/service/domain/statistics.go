@@ -1,7 +1,39 @@
 package domain
 
+import "time"
+
+type StatisticsBucket string
+
+const (
+	StatisticsBucketDay  StatisticsBucket = "day"
+	StatisticsBucketWeek StatisticsBucket = "week"
+)
+
+// StatisticsFilter narrows statistics to a team's PRs and to PRs created
+// within the period. Nil bounds leave the period open. TeamID is resolved
+// from TeamName by the service. IncludeIdle also lists active users without
+// assignments and, with a team set, keeps only its members.
+type StatisticsFilter struct {
+	From        *time.Time
+	To          *time.Time
+	TeamName    string
+	Bucket      StatisticsBucket
+	TeamID      int
+	IncludeIdle bool
+}
+
+// StatisticsPoint counts what happened within the bucket starting at Start.
+type StatisticsPoint struct {
+	Start       time.Time
+	CreatedPRs  int
+	MergedPRs   int
+	Assignments int
+}
+
 type Statistics struct {
 	UserAssignments []UserAssignmentStat
+	Series          []StatisticsPoint
+	Bucket          StatisticsBucket
 	TotalPRs        int
 	OpenPRs         int
 	MergedPRs       int
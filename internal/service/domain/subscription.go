@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// EventType names an event other tools can subscribe to.
+type EventType string
+
+const (
+	EventReviewersAssigned  EventType = "reviewers.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventReviewerUnassigned EventType = "reviewer.unassigned"
+	EventPRMerged           EventType = "pr.merged"
+)
+
+var EventTypes = []EventType{EventReviewersAssigned, EventReviewerReassigned, EventReviewerUnassigned, EventPRMerged}
+
+func (t EventType) Valid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// OutboxEvent is a PR change recorded in the same transaction as the change
+// itself. Reviewers are the PR's reviewers after the change; OldReviewerID
+// is only set for reassignments and unassignments, NewReviewerID only for
+// reassignments.
+type OutboxEvent struct {
+	CreatedAt     time.Time
+	Type          EventType
+	PRID          string
+	PRName        string
+	AuthorID      string
+	OldReviewerID string
+	NewReviewerID string
+	Reviewers     []string
+	ID            int64
+}
+
+type Subscription struct {
+	CreatedAt  *time.Time
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	ID         int64
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// SubscriptionDelivery is an event sent, or still to be sent, to one
+// subscription.
+type SubscriptionDelivery struct {
+	NextAttemptAt  time.Time
+	CreatedAt      *time.Time
+	DeliveredAt    *time.Time
+	Subscription   Subscription
+	Event          OutboxEvent
+	Status         DeliveryStatus
+	LastError      string
+	ID             int64
+	Attempts       int
+	LastStatusCode int
+}
+
+// DeliveryAttempt records a failed delivery attempt: the delivery is
+// retried after RetryIn unless GiveUp is set. StatusCode is zero when no
+// response was received.
+type DeliveryAttempt struct {
+	Error      string
+	StatusCode int
+	RetryIn    time.Duration
+	GiveUp     bool
+}
@@ -1,14 +1,165 @@
 package domain
 
+import "time"
+
+// Team is a group of reviewers. A team may have a parent team; ParentID is 0
+// for top-level teams. Settings holds the team's own settings and Effective
+// the settings after inheritance, when resolved. The member counts are only
+// filled in team listings.
 type Team struct {
-	Name    string
-	Members []Member
-	ID      int
+	Name              string
+	ParentName        string
+	Members           []Member
+	Settings          TeamSettings
+	Effective         TeamSettings
+	ID                int
+	ParentID          int
+	MemberCount       int
+	ActiveMemberCount int
+	// ExistingUsers lists the added members who were already registered
+	// when the team was created or updated. They joined as they were,
+	// without their name or activity changing.
+	ExistingUsers []string
+}
+
+// TeamFilter selects a page of teams. Name matches team names
+// case-insensitively; members are only loaded when WithMembers is set.
+type TeamFilter struct {
+	Name        string
+	Limit       int
+	Offset      int
+	WithMembers bool
+}
+
+// TeamPage is a page of teams and the number of teams matching the filter.
+type TeamPage struct {
+	Teams  []*Team
+	Total  int
+	Limit  int
+	Offset int
+}
+
+// ReviewerFallback decides where reviewers come from when a team runs out of
+// candidates.
+type ReviewerFallback string
+
+const (
+	ReviewerFallbackNone   ReviewerFallback = "none"
+	ReviewerFallbackParent ReviewerFallback = "parent"
+)
+
+// TeamSettings are per-team settings. Nil fields are inherited from the
+// parent team.
+type TeamSettings struct {
+	ReviewSLA        *time.Duration
+	ReviewerFallback *ReviewerFallback
+}
+
+// Inherit fills the settings left unset from the parent's settings.
+func (s TeamSettings) Inherit(parent TeamSettings) TeamSettings {
+	if s.ReviewSLA == nil {
+		s.ReviewSLA = parent.ReviewSLA
+	}
+	if s.ReviewerFallback == nil {
+		s.ReviewerFallback = parent.ReviewerFallback
+	}
+
+	return s
 }
 
+// FallbackToParent reports whether reviewers may be taken from the parent
+// team.
+func (s TeamSettings) FallbackToParent() bool {
+	return s.ReviewerFallback != nil && *s.ReviewerFallback == ReviewerFallbackParent
+}
+
+// ResolveSettings returns the effective settings of the first team in the
+// chain, which lists a team followed by its ancestors, nearest first.
+func ResolveSettings(chain []*Team) TeamSettings {
+	var settings TeamSettings
+	for _, team := range chain {
+		settings = settings.Inherit(team.Settings)
+	}
+
+	return settings
+}
+
+// TeamNode is a team in the hierarchy. The totals cover the team and all of
+// its descendants; a user in several teams of one subtree is counted once.
+type TeamNode struct {
+	Name             string
+	Children         []*TeamNode
+	ID               int
+	ParentID         int
+	MemberCount      int
+	TotalMemberCount int
+	OpenReviews      int
+	TotalOpenReviews int
+}
+
+// Member is a user seen from one team. TeamID is that team, Teams lists all
+// of the user's memberships.
 type Member struct {
-	UserID   string
-	Username string
-	TeamID   int
-	IsActive bool
+	UserID       string
+	Username     string
+	Teams        []TeamMembership
+	WorkingHours WorkingHours
+	TeamID       int
+	IsActive     bool
+}
+
+// TeamNames returns the names of all teams the member belongs to.
+func (m *Member) TeamNames() []string {
+	return membershipNames(m.Teams)
+}
+
+// OpenReviewsPolicy decides what happens to open reviews of users who leave
+// a team.
+type OpenReviewsPolicy string
+
+const (
+	OpenReviewsReject OpenReviewsPolicy = "reject"
+	// OpenReviewsUnassign drops the open reviews without picking
+	// replacements; the PRs may end up with fewer reviewers than required.
+	OpenReviewsUnassign OpenReviewsPolicy = "unassign"
+	OpenReviewsKeep     OpenReviewsPolicy = "keep"
+	OpenReviewsReassign OpenReviewsPolicy = "reassign"
+)
+
+// TeamUpdate describes changes to a team. A nil ParentName keeps the parent
+// and an empty one detaches the team; a nil Settings keeps the settings,
+// otherwise they are replaced as a whole.
+type TeamUpdate struct {
+	NewName           string
+	ParentName        *string
+	Settings          *TeamSettings
+	AddMembers        []Member
+	RemoveMembers     []string
+	OpenReviewsPolicy OpenReviewsPolicy
+}
+
+// TeamMove reports the effects of moving a user to another team.
+type TeamMove struct {
+	UserID            string
+	FromTeam          string
+	ToTeam            string
+	Policy            OpenReviewsPolicy
+	KeptReviews       []string
+	UnassignedReviews []string
+	ReassignedReviews []Reassignment
+}
+
+type Reassignment struct {
+	PRID          string
+	NewReviewerID string
+}
+
+// ReviewerReplacement hands a reviewer's open review on the PR to a new
+// reviewer chosen by the decisions.
+type ReviewerReplacement struct {
+	PRID          string
+	OldReviewerID string
+	NewReviewerID string
+	Reason        string
+	Decisions     []*AssignmentDecision
 }
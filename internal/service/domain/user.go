@@ -1,9 +1,94 @@
 package domain
 
+import "slices"
+
+// User is a reviewer. OpenReviews is the number of open PRs the user is
+// currently assigned to review.
 type User struct {
-	ID       string
-	Username string
+	ID           string
+	Username     string
+	Email        string
+	Teams        []TeamMembership
+	WorkingHours WorkingHours
+	Role         Role
+	OpenReviews  int
+	IsActive     bool
+	// DigestEnabled is the user's choice to receive the daily review digest,
+	// which is only sent when they also have an email.
+	DigestEnabled bool
+}
+
+// EmailSettings changes a user's email. A nil DigestEnabled leaves the
+// digest choice unchanged; an empty Email removes the email.
+type EmailSettings struct {
+	Email         string
+	DigestEnabled *bool
+}
+
+// UserFilter narrows a user listing. Zero values do not filter; Search
+// matches the user ID or username case-insensitively.
+type UserFilter struct {
+	TeamName string
+	Search   string
+	IsActive *bool
+}
+
+type TeamMembership struct {
 	TeamName string
-	TeamID   int
-	IsActive bool
+	// Role is RoleTeamLead or RoleMember.
+	Role   Role
+	TeamID int
+}
+
+// TeamNames returns the names of all teams the user belongs to.
+func (u *User) TeamNames() []string {
+	return membershipNames(u.Teams)
+}
+
+// LedTeamNames returns the names of the teams the user leads.
+func (u *User) LedTeamNames() []string {
+	var names []string
+	for _, membership := range u.Teams {
+		if membership.Role == RoleTeamLead {
+			names = append(names, membership.TeamName)
+		}
+	}
+
+	return names
+}
+
+// InTeam reports whether the user is a member of the team.
+func (u *User) InTeam(teamId int) bool {
+	for _, membership := range u.Teams {
+		if membership.TeamID == teamId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Leads reports whether the user may manage any of the teams: admins manage
+// every team, team leads the teams they lead.
+func (u *User) Leads(teamIds ...int) bool {
+	if u.Role == RoleAdmin {
+		return true
+	}
+
+	for _, membership := range u.Teams {
+		if membership.Role == RoleTeamLead && slices.Contains(teamIds, membership.TeamID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func membershipNames(memberships []TeamMembership) []string {
+	names := make([]string, len(memberships))
+	for i, membership := range memberships {
+		names[i] = membership.TeamName
+	}
+
+	return names
 }
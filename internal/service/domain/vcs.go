@@ -0,0 +1,51 @@
+package domain
+
+// VCSAction is a pull request lifecycle change reported by a VCS provider,
+// normalized across providers.
+type VCSAction string
+
+const (
+	// VCSActionOpened covers every event after which the PR is open and may
+	// be ready for review: opened, marked ready or updated.
+	VCSActionOpened   VCSAction = "opened"
+	VCSActionClosed   VCSAction = "closed"
+	VCSActionMerged   VCSAction = "merged"
+	VCSActionReopened VCSAction = "reopened"
+)
+
+// VCSEvent is a pull request event from GitHub, GitLab or Gitea. PRID is
+// derived from the provider's own ID, while Repository and Number locate the
+// PR on the code host. AuthorID is the mapped user ID and is empty when the
+// provider's author is not mapped; AuthorLogin keeps the provider's name for
+// reporting.
+type VCSEvent struct {
+	Provider    string
+	DeliveryID  string
+	Action      VCSAction
+	PRID        string
+	Repository  string
+	Title       string
+	AuthorID    string
+	AuthorLogin string
+	Number      int
+	Draft       bool
+}
+
+type VCSOutcome string
+
+const (
+	VCSOutcomeCreated   VCSOutcome = "created"
+	VCSOutcomeExists    VCSOutcome = "exists"
+	VCSOutcomeMerged    VCSOutcome = "merged"
+	VCSOutcomeClosed    VCSOutcome = "closed"
+	VCSOutcomeReopened  VCSOutcome = "reopened"
+	VCSOutcomeIgnored   VCSOutcome = "ignored"
+	VCSOutcomeDuplicate VCSOutcome = "duplicate"
+)
+
+// VCSEventResult tells what an event changed. Reason explains ignored
+// events.
+type VCSEventResult struct {
+	Outcome VCSOutcome
+	Reason  string
+}
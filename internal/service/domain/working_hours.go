@@ -0,0 +1,113 @@
+package domain
+
+import "time"
+
+const (
+	DefaultTimezone  = "UTC"
+	DefaultWorkStart = 9
+	DefaultWorkEnd   = 18
+)
+
+// WorkingHours describes a user's working day in their own timezone. Hours
+// are whole hours in [0, 24]; when StartHour is greater than EndHour the day
+// wraps past midnight, and equal hours, as in the zero value, cover the whole
+// day. Saturdays and Sundays are days off.
+type WorkingHours struct {
+	Timezone  string
+	StartHour int
+	EndHour   int
+}
+
+func (wh WorkingHours) Location() *time.Location {
+	loc, err := time.LoadLocation(wh.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Contains reports whether t falls within the working hours.
+func (wh WorkingHours) Contains(t time.Time) bool {
+	local := t.In(wh.Location())
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		for _, window := range wh.windows(day) {
+			if !local.Before(window[0]) && local.Before(window[1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// BusinessDuration returns how much of [from, to) falls within the working
+// hours.
+func (wh WorkingHours) BusinessDuration(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	loc := wh.Location()
+	from = from.In(loc)
+	to = to.In(loc)
+
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, -1)
+	for !day.After(to) {
+		for _, window := range wh.windows(day) {
+			start := maxTime(window[0], from)
+			end := minTime(window[1], to)
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return total
+}
+
+// DayStart returns when the working day on t's calendar day starts in the
+// user's timezone, and false on days off.
+func (wh WorkingHours) DayStart(t time.Time) (time.Time, bool) {
+	windows := wh.windows(t.In(wh.Location()))
+	if len(windows) == 0 {
+		return time.Time{}, false
+	}
+
+	return windows[0][0], true
+}
+
+// windows returns the working intervals that start on the calendar day of t.
+// Bounds are wall-clock hours, so a window keeps its hours on days when the
+// clocks change.
+func (wh WorkingHours) windows(t time.Time) [][2]time.Time {
+	year, month, day := t.Date()
+	if weekday := t.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return nil
+	}
+
+	start := time.Date(year, month, day, wh.StartHour, 0, 0, 0, t.Location())
+	if wh.StartHour < wh.EndHour {
+		return [][2]time.Time{{start, time.Date(year, month, day, wh.EndHour, 0, 0, 0, t.Location())}}
+	}
+
+	return [][2]time.Time{{start, time.Date(year, month, day+1, wh.EndHour, 0, 0, 0, t.Location())}}
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+
+	return loc
+}
+
+// Cairo moves its clocks on weekdays: forward at midnight on Friday
+// 2026-04-24 and back at midnight on Thursday 2026-10-29.
+func TestWorkingHoursContains(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	cairo := mustLocation(t, "Africa/Cairo")
+
+	office := WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18}
+	nights := WorkingHours{Timezone: "Europe/Berlin", StartHour: 22, EndHour: 6}
+	cairoOffice := WorkingHours{Timezone: "Africa/Cairo", StartHour: 9, EndHour: 18}
+
+	tests := []struct {
+		name string
+		wh   WorkingHours
+		t    time.Time
+		want bool
+	}{
+		{name: "within the day", wh: office, t: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), want: true},
+		{name: "at the start", wh: office, t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), want: true},
+		{name: "before the start", wh: office, t: time.Date(2026, 3, 2, 8, 59, 0, 0, time.UTC), want: false},
+		{name: "at the end", wh: office, t: time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC), want: false},
+		{name: "saturday", wh: office, t: time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC), want: false},
+		{name: "sunday", wh: office, t: time.Date(2026, 3, 8, 10, 0, 0, 0, time.UTC), want: false},
+		{
+			name: "other timezone",
+			wh:   WorkingHours{Timezone: "Europe/Berlin", StartHour: 9, EndHour: 18},
+			t:    time.Date(2026, 3, 2, 8, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		{name: "night before midnight", wh: nights, t: time.Date(2026, 3, 3, 23, 0, 0, 0, berlin), want: true},
+		{name: "night after midnight", wh: nights, t: time.Date(2026, 3, 4, 5, 0, 0, 0, berlin), want: true},
+		{name: "after the night", wh: nights, t: time.Date(2026, 3, 4, 7, 0, 0, 0, berlin), want: false},
+		{name: "friday night into saturday", wh: nights, t: time.Date(2026, 3, 7, 2, 0, 0, 0, berlin), want: true},
+		{name: "sunday night into monday", wh: nights, t: time.Date(2026, 3, 9, 2, 0, 0, 0, berlin), want: false},
+		{name: "dst start, morning", wh: cairoOffice, t: time.Date(2026, 4, 24, 9, 30, 0, 0, cairo), want: true},
+		{name: "dst start, evening", wh: cairoOffice, t: time.Date(2026, 4, 24, 18, 30, 0, 0, cairo), want: false},
+		{name: "zero value on a weekday", wh: WorkingHours{}, t: time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC), want: true},
+		{name: "zero value on a weekend", wh: WorkingHours{}, t: time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC), want: false},
+		{
+			name: "unknown timezone falls back to UTC",
+			wh:   WorkingHours{Timezone: "Mars/Olympus", StartHour: 9, EndHour: 18},
+			t:    time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.wh.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%s) = %t, want %t", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursBusinessDuration(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	cairo := mustLocation(t, "Africa/Cairo")
+
+	office := WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18}
+	nights := WorkingHours{Timezone: "Europe/Berlin", StartHour: 22, EndHour: 6}
+
+	tests := []struct {
+		name string
+		wh   WorkingHours
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{
+			name: "one day",
+			wh:   office,
+			from: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC),
+			want: 9 * time.Hour,
+		},
+		{
+			name: "over the weekend",
+			wh:   office,
+			from: time.Date(2026, 3, 6, 17, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC),
+			want: 2 * time.Hour,
+		},
+		{
+			name: "from after to",
+			wh:   office,
+			from: time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			name: "empty period",
+			wh:   office,
+			from: time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			name: "night across midnight",
+			wh:   nights,
+			from: time.Date(2026, 3, 3, 21, 0, 0, 0, berlin),
+			to:   time.Date(2026, 3, 4, 7, 0, 0, 0, berlin),
+			want: 8 * time.Hour,
+		},
+		{
+			name: "friday night runs into saturday",
+			wh:   nights,
+			from: time.Date(2026, 3, 6, 20, 0, 0, 0, berlin),
+			to:   time.Date(2026, 3, 9, 0, 0, 0, 0, berlin),
+			want: 8 * time.Hour,
+		},
+		{
+			name: "dst start keeps wall-clock hours",
+			wh:   WorkingHours{Timezone: "Africa/Cairo", StartHour: 9, EndHour: 18},
+			from: time.Date(2026, 4, 24, 9, 0, 0, 0, cairo),
+			to:   time.Date(2026, 4, 24, 10, 0, 0, 0, cairo),
+			want: time.Hour,
+		},
+		{
+			name: "dst end lengthens the night",
+			wh:   WorkingHours{Timezone: "Africa/Cairo", StartHour: 22, EndHour: 6},
+			from: time.Date(2026, 10, 29, 20, 0, 0, 0, cairo),
+			to:   time.Date(2026, 10, 30, 10, 0, 0, 0, cairo),
+			want: 9 * time.Hour,
+		},
+		{
+			name: "zero value counts whole weekdays",
+			wh:   WorkingHours{},
+			from: time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC),
+			want: 24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.wh.BusinessDuration(tt.from, tt.to); got != tt.want {
+				t.Errorf("BusinessDuration = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursDayStart(t *testing.T) {
+	cairo := mustLocation(t, "Africa/Cairo")
+
+	tests := []struct {
+		name   string
+		wh     WorkingHours
+		t      time.Time
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "weekday",
+			wh:     WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18},
+			t:      time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+			want:   time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name: "weekend",
+			wh:   WorkingHours{Timezone: "UTC", StartHour: 9, EndHour: 18},
+			t:    time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "dst start",
+			wh:     WorkingHours{Timezone: "Africa/Cairo", StartHour: 9, EndHour: 18},
+			t:      time.Date(2026, 4, 24, 12, 0, 0, 0, cairo),
+			want:   time.Date(2026, 4, 24, 9, 0, 0, 0, cairo),
+			wantOk: true,
+		},
+		{
+			name:   "zero value starts at midnight",
+			wh:     WorkingHours{},
+			t:      time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+			want:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.wh.DayStart(tt.t)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("DayStart = %s, %t, want %s, %t", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
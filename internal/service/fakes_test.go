@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type fakePRs struct {
+	service.PRProvider
+	prs map[string]domain.PR
+}
+
+func (f *fakePRs) Create(_ context.Context, pr domain.PR, _ []*domain.AssignmentDecision) (*domain.PR, error) {
+	if _, ok := f.prs[pr.ID]; ok {
+		return nil, repository.ErrPRExists
+	}
+	f.prs[pr.ID] = pr
+
+	return &pr, nil
+}
+
+func (f *fakePRs) Get(_ context.Context, prId string) (*domain.PR, error) {
+	pr, ok := f.prs[prId]
+	if !ok {
+		return nil, repository.ErrPRNotFound
+	}
+
+	return &pr, nil
+}
+
+func (f *fakePRs) setStatus(prId string, status domain.PRStatus) (*domain.PR, error) {
+	pr, ok := f.prs[prId]
+	if !ok {
+		return nil, repository.ErrPRNotFound
+	}
+	pr.Status = status
+	f.prs[prId] = pr
+
+	return &pr, nil
+}
+
+func (f *fakePRs) Merge(_ context.Context, prId string, _ string) (*domain.PR, error) {
+	return f.setStatus(prId, domain.PRStatusMerged)
+}
+
+func (f *fakePRs) ClosePR(_ context.Context, prId string) (*domain.PR, error) {
+	return f.setStatus(prId, domain.PRStatusClosed)
+}
+
+func (f *fakePRs) ReopenPR(_ context.Context, prId string) (*domain.PR, error) {
+	return f.setStatus(prId, domain.PRStatusOpen)
+}
+
+type fakeUsers struct {
+	service.UserProvider
+}
+
+func (fakeUsers) GetUser(_ context.Context, userId string) (*domain.User, error) {
+	if userId != "u1" {
+		return nil, repository.ErrUserNotFound
+	}
+
+	return &domain.User{
+		ID:       "u1",
+		IsActive: true,
+		Teams:    []domain.TeamMembership{{TeamID: 1, TeamName: "platform", Role: domain.RoleMember}},
+	}, nil
+}
+
+type fakeTeams struct {
+	service.TeamProvider
+}
+
+var platform = &domain.Team{
+	ID:   1,
+	Name: "platform",
+	Members: []domain.Member{
+		{UserID: "u1", TeamID: 1, IsActive: true},
+		{UserID: "u2", TeamID: 1, IsActive: true},
+		{UserID: "u3", TeamID: 1, IsActive: false},
+	},
+}
+
+func (fakeTeams) GetTeamById(_ context.Context, teamId int) (*domain.Team, error) {
+	if teamId != platform.ID {
+		return nil, repository.ErrTeamNotFound
+	}
+
+	return platform, nil
+}
+
+func (fakeTeams) GetTeamChain(_ context.Context, _ int) ([]*domain.Team, error) {
+	return []*domain.Team{platform}, nil
+}
+
+type fakeWebhooks struct {
+	claimed map[string]bool
+}
+
+func (f *fakeWebhooks) ClaimDelivery(_ context.Context, provider string, deliveryId string) (bool, error) {
+	key := provider + "/" + deliveryId
+	if f.claimed[key] {
+		return false, nil
+	}
+	f.claimed[key] = true
+
+	return true, nil
+}
+
+func (f *fakeWebhooks) ReleaseDelivery(_ context.Context, provider string, deliveryId string) error {
+	delete(f.claimed, provider+"/"+deliveryId)
+	return nil
+}
+
+type reviewerConfig struct{}
+
+func (reviewerConfig) PairingWindow() time.Duration { return 0 }
+func (reviewerConfig) ReviewSLA() time.Duration     { return 0 }
+
+// fakeReviewRequests keeps the review request queue in memory and records
+// every attempt in order.
+type fakeReviewRequests struct {
+	queued   map[int64]*domain.QueuedReviewRequest
+	attempts []domain.ReviewRequestAttempt
+	nextId   int64
+}
+
+func (f *fakeReviewRequests) EnqueueReviewRequest(
+	_ context.Context,
+	request domain.ReviewRequest,
+	attempt domain.ReviewRequestAttempt,
+) error {
+	f.nextId++
+	f.queued[f.nextId] = &domain.QueuedReviewRequest{ReviewRequest: request, ID: f.nextId}
+
+	return f.UpdateReviewRequest(context.Background(), f.nextId, attempt)
+}
+
+func (f *fakeReviewRequests) ClaimReviewRequests(
+	_ context.Context,
+	limit int,
+	_ time.Duration,
+) ([]*domain.QueuedReviewRequest, error) {
+	var claimed []*domain.QueuedReviewRequest
+	for _, request := range f.queued {
+		if request.FailedAt == nil {
+			claim := *request
+			claimed = append(claimed, &claim)
+		}
+	}
+	slices.SortFunc(claimed, func(a, b *domain.QueuedReviewRequest) int {
+		return int(a.ID - b.ID)
+	})
+
+	return claimed[:min(limit, len(claimed))], nil
+}
+
+func (f *fakeReviewRequests) UpdateReviewRequest(_ context.Context, id int64, attempt domain.ReviewRequestAttempt) error {
+	request := f.queued[id]
+	request.Attempts++
+	request.LastError = attempt.Error
+	if attempt.GiveUp {
+		failedAt := time.Now()
+		request.FailedAt = &failedAt
+	}
+	f.attempts = append(f.attempts, attempt)
+
+	return nil
+}
+
+func (f *fakeReviewRequests) DeleteReviewRequest(_ context.Context, id int64) error {
+	delete(f.queued, id)
+	return nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	notificationTimeout = 5 * time.Second
+
+	notificationBatch       = 50
+	notificationLease       = time.Minute
+	notificationMaxAttempts = 8
+	notificationBaseDelay   = 30 * time.Second
+	notificationMaxDelay    = 30 * time.Minute
+)
+
+// notifyReviewers queues notifications for the reviewers of a PR. The change
+// is already stored, so a failure never fails the caller.
+func (s *Service) notifyReviewers(ctx context.Context, notifications []domain.Notification) {
+	const op = "internal.service.notification.notifyReviewers"
+
+	if s.notifier == nil || len(notifications) == 0 {
+		return
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", notifications[0].PRID))
+
+	err := s.NotificationProvider.EnqueueNotifications(context.WithoutCancel(ctx), notifications)
+	if err != nil {
+		log.Error("failed to queue notifications", sl.Err(err))
+	}
+}
+
+// assignedNotifications builds the notifications for reviewers assigned to
+// the PR.
+func assignedNotifications(pr *domain.PR, teamName string, reviewers []string) []domain.Notification {
+	notifications := make([]domain.Notification, len(reviewers))
+	for i, reviewer := range reviewers {
+		notifications[i] = domain.Notification{
+			Kind:     domain.NotificationAssigned,
+			PRID:     pr.ID,
+			PRName:   pr.Name,
+			TeamName: teamName,
+			UserID:   reviewer,
+		}
+	}
+
+	return notifications
+}
+
+// NotifyOverdueReviews queues a notification for every review that has
+// waited longer than its team's review SLA, counted in the reviewer's
+// working hours, and reports how many were queued. Each assignment is
+// reported once.
+func (s *Service) NotifyOverdueReviews(ctx context.Context) (int, error) {
+	const op = "internal.service.notification.NotifyOverdueReviews"
+
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	reviews, err := s.NotificationProvider.GetUnreportedReviews(ctx)
+	if err != nil {
+		log.Error("failed to get open reviews", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	users := make(map[string]*domain.User)
+	slas := make(map[int]time.Duration)
+	queued := 0
+	for _, review := range reviews {
+		sla, ok := slas[review.TeamID]
+		if !ok {
+			sla, err = s.teamReviewSLA(ctx, review.TeamID)
+			if err != nil {
+				log.Error("failed to get review sla", sl.Err(err))
+				return queued, fmt.Errorf("%s: %w", op, err)
+			}
+			slas[review.TeamID] = sla
+		}
+		if sla <= 0 {
+			continue
+		}
+
+		user, ok := users[review.UserID]
+		if !ok {
+			user, err = s.UserProvider.GetUser(ctx, review.UserID)
+			if err != nil {
+				log.Error("failed to get user", slog.String("userId", review.UserID), sl.Err(err))
+				return queued, fmt.Errorf("%s: %w", op, err)
+			}
+			users[review.UserID] = user
+		}
+
+		wait := user.WorkingHours.BusinessDuration(review.AssignedAt, now)
+		if wait <= sla {
+			continue
+		}
+
+		err = s.NotificationProvider.EnqueueOverdueNotification(ctx, domain.Notification{
+			Kind:     domain.NotificationOverdue,
+			PRID:     review.PRID,
+			PRName:   review.PRName,
+			TeamName: review.TeamName,
+			UserID:   review.UserID,
+			Wait:     wait,
+		})
+		if err != nil {
+			log.Error("failed to queue overdue notification", slog.String("prId", review.PRID), sl.Err(err))
+			return queued, fmt.Errorf("%s: %w", op, err)
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		log.Info("queued overdue notifications", slog.Int("count", queued))
+	}
+	return queued, nil
+}
+
+// SendNotifications sends the queued notifications that are due and reports
+// how many were sent. Notifications are given up when the chat rejects them
+// or after notificationMaxAttempts attempts.
+func (s *Service) SendNotifications(ctx context.Context) (int, error) {
+	const op = "internal.service.notification.SendNotifications"
+
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	notifications, err := s.NotificationProvider.ClaimNotifications(ctx, notificationBatch, notificationLease)
+	if err != nil {
+		log.Error("failed to claim notifications", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sent := 0
+	for _, notification := range notifications {
+		log := log.With(
+			slog.Int64("id", notification.ID),
+			slog.String("prId", notification.PRID),
+			slog.String("userId", notification.UserID),
+			slog.Int("attempt", notification.Attempts+1))
+
+		err = s.sendNotification(ctx, notification.Notification)
+		if err == nil {
+			if err := s.NotificationProvider.DeleteNotification(ctx, notification.ID); err != nil {
+				log.Error("failed to delete notification", sl.Err(err))
+				return sent, fmt.Errorf("%s: %w", op, err)
+			}
+
+			sent++
+			continue
+		}
+
+		attempt := nextNotificationAttempt(err, notification.Attempts+1)
+		if attempt.GiveUp {
+			log.Error("giving up notification", sl.Err(err))
+		} else {
+			log.Warn("failed to send notification", sl.Err(err), slog.Duration("retryIn", attempt.RetryIn))
+		}
+
+		if err := s.NotificationProvider.UpdateNotification(ctx, notification.ID, attempt); err != nil {
+			log.Error("failed to update notification", sl.Err(err))
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Service) sendNotification(ctx context.Context, notification domain.Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	return s.notifier.Notify(ctx, notification)
+}
+
+// nextNotificationAttempt backs off exponentially from 30 seconds, capped at
+// 30 minutes.
+func nextNotificationAttempt(err error, attempts int) domain.NotificationAttempt {
+	delay := notificationMaxDelay
+	if shift := attempts - 1; shift < 16 {
+		delay = min(notificationBaseDelay<<shift, notificationMaxDelay)
+	}
+
+	return domain.NotificationAttempt{
+		Error:   err.Error(),
+		RetryIn: delay,
+		GiveUp:  errors.Is(err, ErrNotificationRejected) || attempts >= notificationMaxAttempts,
+	}
+}
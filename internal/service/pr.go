@@ -12,15 +12,30 @@ import (
 	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
-func (s *Service) CreatePR(ctx context.Context, prId string, prName string, authorId string) (*domain.PR, error) {
+// CreatePR opens a PR for the team it belongs to and assigns reviewers from
+// that team, falling back to parent teams when the settings allow it.
+// teamName may be empty when the author belongs to a single team.
+func (s *Service) CreatePR(
+	ctx context.Context,
+	prId string,
+	prName string,
+	authorId string,
+	teamName string,
+) (*domain.PR, error) {
+	return s.createPR(ctx, domain.PR{ID: prId, Name: prName, AuthorID: authorId}, teamName)
+}
+
+// createPR registers the PR, notifies its reviewers and pushes them to the
+// code host when the PR came from one.
+func (s *Service) createPR(ctx context.Context, pr domain.PR, teamName string) (*domain.PR, error) {
 	const op = "internal.service.pr.CreatePR"
 
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("prId", prId))
+		slog.String("prId", pr.ID))
 
 	log.Info("attempting to get user")
-	author, err := s.UserProvider.GetUser(ctx, authorId)
+	author, err := s.UserProvider.GetUser(ctx, pr.AuthorID)
 	if errors.Is(err, repository.ErrUserNotFound) {
 		log.Info("author not found")
 		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
@@ -31,33 +46,31 @@ func (s *Service) CreatePR(ctx context.Context, prId string, prName string, auth
 	}
 
 	log.Info("attempting to get team")
-	_, err = s.TeamProvider.GetTeamById(ctx, author.TeamID)
-	if errors.Is(err, repository.ErrTeamNotFound) {
-		log.Warn("team not found")
-		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
-	}
+	team, err := s.resolvePRTeam(ctx, author, teamName)
 	if err != nil {
-		log.Error("failed to get team", sl.Err(err))
+		log.Warn("failed to resolve pr team", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("attempting to get reviewers")
-	reviewers, err := s.UserProvider.GetReviewers(ctx, author.TeamID, []string{authorId}, 2)
+	log.Info("attempting to select reviewers")
+	decisions, reviewers, err := s.selectWithFallback(ctx, selectionRequest{
+		trigger:  domain.AssignmentTriggerCreate,
+		prId:     pr.ID,
+		authorId: pr.AuthorID,
+		actorId:  actorID(ctx),
+		limit:    2,
+	}, team)
 	if err != nil {
-		log.Error("failed to get reviewers", sl.Err(err))
+		log.Error("failed to select reviewers", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	pr := domain.PR{
-		ID:        prId,
-		Name:      prName,
-		AuthorID:  authorId,
-		Status:    domain.PRStatusOpen,
-		Reviewers: reviewers,
-	}
+	pr.Status = domain.PRStatusOpen
+	pr.Reviewers = reviewers
+	pr.TeamID = team.ID
 
 	log.Info("attempting to create pr")
-	prEntity, err := s.PRRepository.Create(ctx, pr)
+	prEntity, err := s.PRRepository.Create(ctx, pr, decisions)
 	if errors.Is(err, repository.ErrPRExists) {
 		log.Warn("pr already exists")
 		return nil, fmt.Errorf("%s: %w", op, ErrPRExists)
@@ -67,10 +80,50 @@ func (s *Service) CreatePR(ctx context.Context, prId string, prName string, auth
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.requestReviewers(ctx, prEntity, reviewers, nil)
+	s.notifyReviewers(ctx, assignedNotifications(prEntity, team.Name, reviewers))
+
 	log.Info("successfully created pr")
 	return prEntity, nil
 }
 
+// resolvePRTeam picks the team a new PR belongs to. Without an explicit team
+// name the author must belong to exactly one team.
+func (s *Service) resolvePRTeam(ctx context.Context, author *domain.User, teamName string) (*domain.Team, error) {
+	const op = "internal.service.pr.resolvePRTeam"
+
+	var (
+		team *domain.Team
+		err  error
+	)
+
+	switch {
+	case teamName != "":
+		team, err = s.TeamProvider.GetTeam(ctx, teamName)
+	case len(author.Teams) == 1:
+		team, err = s.TeamProvider.GetTeamById(ctx, author.Teams[0].TeamID)
+	case len(author.Teams) == 0:
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamRequired)
+	}
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !author.InTeam(team.ID) {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotTeamMember)
+	}
+
+	return team, nil
+}
+
+// Merge marks the PR as merged, attributed to the calling user if any. Users
+// must be admins or leads of the PR's team. Merging an already merged PR
+// returns it unchanged.
 func (s *Service) Merge(ctx context.Context, prId string) (*domain.PR, error) {
 	const op = "internal.service.pr.Merge"
 
@@ -78,8 +131,29 @@ func (s *Service) Merge(ctx context.Context, prId string) (*domain.PR, error) {
 		slog.String("op", op),
 		slog.String("prId", prId))
 
-	log.Info("attempting to merge pr")
-	pr, err := s.PRRepository.Merge(ctx, prId)
+	log.Info("attempting to get pr")
+	pr, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.authorizeLead(ctx, pr.TeamID); err != nil {
+		log.Warn("caller may not merge pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if pr.Status == domain.PRStatusMerged {
+		log.Info("pr is already merged")
+		return pr, nil
+	}
+
+	mergedBy := actorID(ctx)
+
+	log.Info("attempting to merge pr", slog.String("mergedBy", mergedBy))
+	pr, err = s.PRRepository.Merge(ctx, prId, mergedBy)
 	if errors.Is(err, repository.ErrPRNotFound) {
 		log.Warn("pr not found")
 		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
@@ -94,15 +168,157 @@ func (s *Service) Merge(ctx context.Context, prId string) (*domain.PR, error) {
 
 }
 
+// Close marks an open PR as closed without merging. Its reviewers stay
+// recorded but no longer count as open reviews. Closing a closed PR is a
+// no-op.
+func (s *Service) Close(ctx context.Context, prId string) (*domain.PR, error) {
+	const op = "internal.service.pr.Close"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId))
+
+	log.Info("attempting to get pr")
+	pr, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		log.Warn("pr is already merged")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRMerged)
+	case domain.PRStatusClosed:
+		return pr, nil
+	}
+
+	log.Info("attempting to close pr")
+	pr, err = s.PRRepository.ClosePR(ctx, prId)
+	if err != nil {
+		log.Error("failed to close pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully closed pr")
+	return pr, nil
+}
+
+// Reopen moves a closed PR back to open with its previous reviewers.
+// Reopening an open PR is a no-op.
+func (s *Service) Reopen(ctx context.Context, prId string) (*domain.PR, error) {
+	const op = "internal.service.pr.Reopen"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId))
+
+	log.Info("attempting to get pr")
+	pr, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		log.Warn("pr is already merged")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRMerged)
+	case domain.PRStatusOpen:
+		return pr, nil
+	}
+
+	log.Info("attempting to reopen pr")
+	pr, err = s.PRRepository.ReopenPR(ctx, prId)
+	if err != nil {
+		log.Error("failed to reopen pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully reopened pr")
+	return pr, nil
+}
+
+// Reassign hands the reviewer's review on the PR to a new reviewer. Users
+// may only reassign themselves away unless they lead the PR's team.
 func (s *Service) Reassign(ctx context.Context, prId string, oldUserId string) (*domain.PR, error) {
 	const op = "internal.service.pr.Reassign"
 
+	if err := s.authorizeReassign(ctx, prId, oldUserId); err != nil {
+		s.log.Warn("caller may not reassign reviewer",
+			slog.String("op", op), slog.String("prId", prId), sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.reassign(ctx, prId, oldUserId, domain.UnassignReasonReassigned)
+}
+
+// reassign replaces the reviewer; reason is recorded in the PR's timeline as
+// the reason the old reviewer was removed.
+func (s *Service) reassign(ctx context.Context, prId string, oldUserId string, reason string) (*domain.PR, error) {
+	const op = "internal.service.pr.Reassign"
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("prId", prId),
 		slog.String("oldUserId", oldUserId))
 
 	log.Info("attempting to reassign pr")
+	plan, err := s.planReassign(ctx, prId, oldUserId, reason)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.UserProvider.ReplaceReviewer(ctx, plan.replacement)
+	if errors.Is(err, repository.ErrNotReviewer) {
+		log.Warn("user is not reviewer")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotReviewer)
+	}
+	if errors.Is(err, repository.ErrAlreadyReviewer) {
+		log.Warn("new reviewer was assigned concurrently")
+		return nil, fmt.Errorf("%s: %w", op, ErrAlreadyReviewer)
+	}
+	if err != nil {
+		log.Error("failed to replace reviewer", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.announceReassign(ctx, plan)
+
+	log.Info("attempting to get pr")
+	newPr, err := s.PRRepository.Get(ctx, prId)
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully pr reassign")
+	return newPr, nil
+}
+
+// reassignPlan is a reviewer replacement picked but not yet stored.
+type reassignPlan struct {
+	pr          *domain.PR
+	team        *domain.Team
+	replacement domain.ReviewerReplacement
+}
+
+// planReassign picks a new reviewer to take over the old reviewer's review on
+// the PR without storing anything.
+func (s *Service) planReassign(ctx context.Context, prId string, oldUserId string, reason string) (*reassignPlan, error) {
+	const op = "internal.service.pr.planReassign"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId),
+		slog.String("oldUserId", oldUserId))
+
 	log.Info("attempting to get pr")
 	pr, err := s.PRRepository.Get(ctx, prId)
 	if errors.Is(err, repository.ErrPRNotFound) {
@@ -117,9 +333,13 @@ func (s *Service) Reassign(ctx context.Context, prId string, oldUserId string) (
 		log.Warn("pr is already merged")
 		return nil, fmt.Errorf("%s: %w", op, ErrPRMerged)
 	}
+	if pr.Status == domain.PRStatusClosed {
+		log.Warn("pr is closed")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRClosed)
+	}
 
 	log.Info("attempting to get user")
-	oldUser, err := s.UserProvider.GetUser(ctx, oldUserId)
+	_, err = s.UserProvider.GetUser(ctx, oldUserId)
 	if errors.Is(err, repository.ErrUserNotFound) {
 		log.Warn("user not found")
 		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
@@ -134,32 +354,90 @@ func (s *Service) Reassign(ctx context.Context, prId string, oldUserId string) (
 		return nil, fmt.Errorf("%s: %w", op, ErrUserNotReviewer)
 	}
 
-	log.Info("attempting to get reviewer candidates")
-	excludeIds := pr.Reviewers
-	excludeIds = append(excludeIds, pr.AuthorID)
-	reviewerCandidates, err := s.UserProvider.GetReviewers(ctx, oldUser.TeamID, excludeIds, 1)
+	log.Info("attempting to get team")
+	team, err := s.TeamProvider.GetTeamById(ctx, pr.TeamID)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		log.Warn("team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to select reviewer")
+	decisions, reviewers, err := s.selectWithFallback(ctx, selectionRequest{
+		trigger:    domain.AssignmentTriggerReassign,
+		prId:       prId,
+		authorId:   pr.AuthorID,
+		replacedId: oldUserId,
+		actorId:    actorID(ctx),
+		assigned:   pr.Reviewers,
+		limit:      1,
+	}, team)
 	if err != nil {
-		log.Error("failed to get reviewer candidates", sl.Err(err))
+		log.Error("failed to select reviewer", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if len(reviewerCandidates) == 0 {
+	if len(reviewers) == 0 {
 		log.Warn("reviewer candidates not found")
 		return nil, fmt.Errorf("%s: %w", op, ErrNoCandidates)
 	}
 
-	err = s.UserProvider.ReplaceReviewer(ctx, reviewerCandidates[0], oldUserId, prId)
+	return &reassignPlan{
+		pr:   pr,
+		team: team,
+		replacement: domain.ReviewerReplacement{
+			PRID:          prId,
+			OldReviewerID: oldUserId,
+			NewReviewerID: reviewers[0],
+			Reason:        reason,
+			Decisions:     decisions,
+		},
+	}, nil
+}
+
+// announceReassign tells the code host and the new reviewer about a stored
+// replacement.
+func (s *Service) announceReassign(ctx context.Context, plan *reassignPlan) {
+	replacement := plan.replacement
+
+	s.requestReviewers(ctx, plan.pr, []string{replacement.NewReviewerID}, []string{replacement.OldReviewerID})
+	s.notifyReviewers(ctx, []domain.Notification{{
+		Kind:           domain.NotificationReassigned,
+		PRID:           plan.pr.ID,
+		PRName:         plan.pr.Name,
+		TeamName:       plan.team.Name,
+		UserID:         replacement.NewReviewerID,
+		ReplacedUserID: replacement.OldReviewerID,
+	}})
+}
+
+func (s *Service) GetAssignmentLog(ctx context.Context, prId string) ([]*domain.AssignmentDecision, error) {
+	const op = "internal.service.pr.GetAssignmentLog"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId))
+
+	log.Info("attempting to get pr")
+	_, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
 	if err != nil {
-		log.Error("failed to replace reviewer", sl.Err(err))
+		log.Error("failed to get pr", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("attempting to get pr")
-	newPr, err := s.PRRepository.Get(ctx, pr.ID)
+	log.Info("attempting to get assignment log")
+	decisions, err := s.PRRepository.GetAssignmentLog(ctx, prId)
 	if err != nil {
-		log.Error("failed to get pr", sl.Err(err))
+		log.Error("failed to get assignment log", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("successfully pr reassign")
-	return newPr, nil
+	log.Info("successfully got assignment log")
+	return decisions, nil
 }
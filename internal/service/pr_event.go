@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// Review records a reviewer's decision on an open PR. Users may only submit
+// their own reviews.
+func (s *Service) Review(
+	ctx context.Context,
+	prId string,
+	reviewerId string,
+	decision domain.ReviewDecision,
+) ([]*domain.PREvent, error) {
+	const op = "internal.service.pr_event.Review"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId),
+		slog.String("reviewerId", reviewerId))
+
+	switch decision {
+	case domain.ReviewApproved, domain.ReviewChangesRequested, domain.ReviewCommented:
+	default:
+		log.Warn("invalid review decision")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDecision)
+	}
+
+	if err := s.authorizeSelf(ctx, reviewerId); err != nil {
+		log.Warn("caller may not review as the reviewer", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to get pr")
+	pr, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		log.Warn("pr is already merged")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRMerged)
+	case domain.PRStatusClosed:
+		log.Warn("pr is closed")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRClosed)
+	}
+	if !slices.Contains(pr.Reviewers, reviewerId) {
+		log.Warn("user is not reviewer")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotReviewer)
+	}
+
+	log.Info("attempting to add review", slog.String("decision", string(decision)))
+	err = s.PRRepository.AddReview(ctx, prId, reviewerId, decision)
+	if err != nil {
+		log.Error("failed to add review", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to get timeline")
+	events, err := s.PRRepository.GetPRTimeline(ctx, prId)
+	if err != nil {
+		log.Error("failed to get timeline", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully added review")
+	return events, nil
+}
+
+// GetTimeline returns the PR's events, oldest first.
+func (s *Service) GetTimeline(ctx context.Context, prId string) ([]*domain.PREvent, error) {
+	const op = "internal.service.pr_event.GetTimeline"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", prId))
+
+	log.Info("attempting to get pr")
+	_, err := s.PRRepository.Get(ctx, prId)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		log.Warn("pr not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrPRNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get pr", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to get timeline")
+	events, err := s.PRRepository.GetPRTimeline(ctx, prId)
+	if err != nil {
+		log.Error("failed to get timeline", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully got timeline")
+	return events, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	// reviewRequestTimeout bounds the attempt made while assigning, which
+	// runs within the API request.
+	reviewRequestTimeout = 2 * time.Second
+
+	reviewRequestBatch       = 20
+	reviewRequestLease       = 5 * time.Minute
+	reviewRequestMaxAttempts = 10
+	reviewRequestBaseDelay   = time.Minute
+	reviewRequestMaxDelay    = 6 * time.Hour
+)
+
+// requestReviewers pushes a reviewer change to the code host the PR was
+// registered from. The assignment is already stored, so a failure never
+// fails the caller: the request is queued and retried by
+// RetryReviewRequests.
+func (s *Service) requestReviewers(ctx context.Context, pr *domain.PR, reviewers []string, removed []string) {
+	const op = "internal.service.review_request.requestReviewers"
+
+	if pr.Source == nil || len(reviewers)+len(removed) == 0 {
+		return
+	}
+	requester, ok := s.reviewRequesters[pr.Source.Provider]
+	if !ok {
+		return
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prId", pr.ID),
+		slog.String("provider", pr.Source.Provider))
+
+	request := domain.ReviewRequest{
+		PRID:      pr.ID,
+		Source:    *pr.Source,
+		Reviewers: reviewers,
+		Removed:   removed,
+	}
+
+	// The request must not be cut short by the client going away once the
+	// assignment is stored.
+	ctx = context.WithoutCancel(ctx)
+
+	log.Info("attempting to request reviewers")
+	err := s.sendReviewRequest(ctx, requester, request)
+	if err == nil {
+		log.Info("successfully requested reviewers")
+		return
+	}
+
+	log.Warn("failed to request reviewers, queueing retry", sl.Err(err))
+	err = s.ReviewRequestProvider.EnqueueReviewRequest(ctx, request, nextReviewRequestAttempt(err, 1))
+	if err != nil {
+		log.Error("failed to queue review request", sl.Err(err))
+	}
+}
+
+// RetryReviewRequests sends the queued review requests that are due and
+// reports how many were sent. Each request is narrowed to the PR's current
+// reviewers first; requests left with nothing to change, or for PRs that are
+// no longer open, are dropped. Requests are given up when the code host
+// rejects them or after reviewRequestMaxAttempts attempts.
+func (s *Service) RetryReviewRequests(ctx context.Context) (int, error) {
+	const op = "internal.service.review_request.RetryReviewRequests"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	requests, err := s.ReviewRequestProvider.ClaimReviewRequests(ctx, reviewRequestBatch, reviewRequestLease)
+	if err != nil {
+		log.Error("failed to claim review requests", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sent := 0
+	for _, request := range requests {
+		log := log.With(
+			slog.Int64("id", request.ID),
+			slog.String("prId", request.PRID),
+			slog.Int("attempt", request.Attempts+1))
+
+		request.ReviewRequest, err = s.currentReviewRequest(ctx, request.ReviewRequest)
+		if err != nil {
+			log.Error("failed to get pr", sl.Err(err))
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if request.Empty() {
+			if err := s.ReviewRequestProvider.DeleteReviewRequest(ctx, request.ID); err != nil {
+				log.Error("failed to delete review request", sl.Err(err))
+				return sent, fmt.Errorf("%s: %w", op, err)
+			}
+
+			log.Info("review request is out of date, dropped")
+			continue
+		}
+
+		requester, ok := s.reviewRequesters[request.Source.Provider]
+		if !ok {
+			err = fmt.Errorf("no review requester for provider %q", request.Source.Provider)
+		} else {
+			err = s.sendReviewRequest(ctx, requester, request.ReviewRequest)
+		}
+
+		if err == nil {
+			if err := s.ReviewRequestProvider.DeleteReviewRequest(ctx, request.ID); err != nil {
+				log.Error("failed to delete review request", sl.Err(err))
+				return sent, fmt.Errorf("%s: %w", op, err)
+			}
+
+			log.Info("successfully requested reviewers")
+			sent++
+			continue
+		}
+
+		attempt := nextReviewRequestAttempt(err, request.Attempts+1)
+		if !ok {
+			attempt.GiveUp = true
+		}
+		if attempt.GiveUp {
+			log.Error("giving up review request", sl.Err(err))
+		} else {
+			log.Warn("failed to request reviewers", sl.Err(err), slog.Duration("retryIn", attempt.RetryIn))
+		}
+
+		if err := s.ReviewRequestProvider.UpdateReviewRequest(ctx, request.ID, attempt); err != nil {
+			log.Error("failed to update review request", sl.Err(err))
+			return sent, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return sent, nil
+}
+
+// currentReviewRequest narrows the request to the PR's current reviewers.
+// Nothing is left to request once the PR is gone or no longer open.
+func (s *Service) currentReviewRequest(ctx context.Context, request domain.ReviewRequest) (domain.ReviewRequest, error) {
+	outdated := domain.ReviewRequest{PRID: request.PRID, Source: request.Source}
+
+	pr, err := s.PRRepository.Get(ctx, request.PRID)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		return outdated, nil
+	}
+	if err != nil {
+		return domain.ReviewRequest{}, err
+	}
+	if pr.Status != domain.PRStatusOpen {
+		return outdated, nil
+	}
+
+	return request.Narrow(pr.Reviewers), nil
+}
+
+func (s *Service) sendReviewRequest(ctx context.Context, requester ReviewRequester, request domain.ReviewRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, reviewRequestTimeout)
+	defer cancel()
+
+	return requester.RequestReviewers(ctx, request)
+}
+
+// nextReviewRequestAttempt backs off exponentially from a minute, capped at
+// six hours.
+func nextReviewRequestAttempt(err error, attempts int) domain.ReviewRequestAttempt {
+	delay := reviewRequestMaxDelay
+	if shift := attempts - 1; shift < 16 {
+		delay = min(reviewRequestBaseDelay<<shift, reviewRequestMaxDelay)
+	}
+
+	return domain.ReviewRequestAttempt{
+		Error:   err.Error(),
+		RetryIn: delay,
+		GiveUp:  errors.Is(err, ErrReviewRequestRejected) || attempts >= reviewRequestMaxAttempts,
+	}
+}
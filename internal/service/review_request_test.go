@@ -0,0 +1,243 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/client/fake"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+var githubSource = domain.PRSource{Provider: "github", Repository: "acme/pr-reviewer", Number: 7}
+
+type reviewRequestFixture struct {
+	svc       *service.Service
+	prs       *fakePRs
+	queue     *fakeReviewRequests
+	requester *fake.ReviewRequester
+}
+
+func newReviewRequestFixture(t *testing.T) reviewRequestFixture {
+	t.Helper()
+
+	f := reviewRequestFixture{
+		prs:       &fakePRs{prs: map[string]domain.PR{}},
+		queue:     &fakeReviewRequests{queued: map[int64]*domain.QueuedReviewRequest{}},
+		requester: fake.NewReviewRequester("github"),
+	}
+	providers := service.Providers{
+		PR:            f.prs,
+		Team:          fakeTeams{},
+		User:          fakeUsers{},
+		Webhook:       &fakeWebhooks{claimed: map[string]bool{}},
+		ReviewRequest: f.queue,
+	}
+	clients := service.Clients{ReviewRequesters: []service.ReviewRequester{f.requester}}
+	f.svc = service.New(slog.New(slog.NewTextHandler(io.Discard, nil)), providers, clients, reviewerConfig{})
+
+	return f
+}
+
+// open registers a PR from GitHub; u2 is the only reviewer it can get.
+func (f reviewRequestFixture) open(t *testing.T) {
+	t.Helper()
+
+	result, err := f.svc.HandleVCSEvent(context.Background(), domain.VCSEvent{
+		Provider:   githubSource.Provider,
+		DeliveryID: "delivery-1",
+		Action:     domain.VCSActionOpened,
+		PRID:       "github-7",
+		Repository: githubSource.Repository,
+		Title:      "Add team hierarchy",
+		AuthorID:   "u1",
+		Number:     githubSource.Number,
+	})
+	if err != nil {
+		t.Fatalf("HandleVCSEvent: %v", err)
+	}
+	if result.Outcome != domain.VCSOutcomeCreated {
+		t.Fatalf("outcome = %s, want %s", result.Outcome, domain.VCSOutcomeCreated)
+	}
+}
+
+func (f reviewRequestFixture) retry(t *testing.T) int {
+	t.Helper()
+
+	sent, err := f.svc.RetryReviewRequests(context.Background())
+	if err != nil {
+		t.Fatalf("RetryReviewRequests: %v", err)
+	}
+
+	return sent
+}
+
+func TestRequestReviewersQueuesFailedRequest(t *testing.T) {
+	f := newReviewRequestFixture(t)
+	f.requester.SetErr(errors.New("connection reset"))
+
+	f.open(t)
+
+	if len(f.queue.queued) != 1 {
+		t.Fatalf("queued %d requests, want 1", len(f.queue.queued))
+	}
+	queued := f.queue.queued[1]
+	want := domain.ReviewRequest{PRID: "github-7", Source: githubSource, Reviewers: []string{"u2"}}
+	if queued.PRID != want.PRID || queued.Source != want.Source ||
+		!slices.Equal(queued.Reviewers, want.Reviewers) || len(queued.Removed) != 0 {
+		t.Errorf("queued = %+v, want %+v", queued.ReviewRequest, want)
+	}
+	if queued.Attempts != 1 || queued.LastError != "connection reset" || queued.FailedAt != nil {
+		t.Errorf("queued attempts = %d, error = %q, failed = %v", queued.Attempts, queued.LastError, queued.FailedAt)
+	}
+	if attempt := f.queue.attempts[0]; attempt.RetryIn != time.Minute || attempt.GiveUp {
+		t.Errorf("first attempt = %+v, want a retry in a minute", attempt)
+	}
+
+	f.requester.SetErr(nil)
+	if sent := f.retry(t); sent != 1 {
+		t.Fatalf("sent %d requests, want 1", sent)
+	}
+	if requests := f.requester.Requests(); len(requests) != 1 || !slices.Equal(requests[0].Reviewers, want.Reviewers) {
+		t.Errorf("requested %+v, want %+v", requests, want)
+	}
+	if len(f.queue.queued) != 0 {
+		t.Errorf("%d requests left in the queue, want none", len(f.queue.queued))
+	}
+}
+
+func TestRetryReviewRequestsBacksOffUntilTheAttemptLimit(t *testing.T) {
+	f := newReviewRequestFixture(t)
+	f.requester.SetErr(errors.New("bad gateway"))
+	f.open(t)
+
+	for range 20 {
+		if sent := f.retry(t); sent != 0 {
+			t.Fatalf("sent %d requests, want none", sent)
+		}
+	}
+
+	wantDelays := []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute,
+		32 * time.Minute, 64 * time.Minute, 128 * time.Minute, 256 * time.Minute, 6 * time.Hour,
+	}
+	if len(f.queue.attempts) != len(wantDelays) {
+		t.Fatalf("made %d attempts, want %d", len(f.queue.attempts), len(wantDelays))
+	}
+	for i, attempt := range f.queue.attempts {
+		if attempt.RetryIn != wantDelays[i] {
+			t.Errorf("attempt %d retries in %s, want %s", i+1, attempt.RetryIn, wantDelays[i])
+		}
+		if last := i == len(wantDelays)-1; attempt.GiveUp != last {
+			t.Errorf("attempt %d gives up = %t, want %t", i+1, attempt.GiveUp, last)
+		}
+	}
+	if queued := f.queue.queued[1]; queued.FailedAt == nil || queued.Attempts != len(wantDelays) {
+		t.Errorf("queued = %+v, want it given up after %d attempts", queued, len(wantDelays))
+	}
+}
+
+func TestRetryReviewRequestsGivesUpWhenRejected(t *testing.T) {
+	f := newReviewRequestFixture(t)
+	f.requester.SetErr(errors.New("timeout"))
+	f.open(t)
+
+	f.requester.SetErr(fmt.Errorf("%w: reviewer is not a collaborator", service.ErrReviewRequestRejected))
+	f.retry(t)
+
+	if len(f.queue.attempts) != 2 || !f.queue.attempts[1].GiveUp {
+		t.Fatalf("attempts = %+v, want the second one to give up", f.queue.attempts)
+	}
+	if f.queue.queued[1].FailedAt == nil {
+		t.Error("rejected request is still pending")
+	}
+
+	f.retry(t)
+	if len(f.queue.attempts) != 2 {
+		t.Errorf("made %d attempts, want no more after giving up", len(f.queue.attempts))
+	}
+}
+
+func TestRetryReviewRequestsAsksOnlyCurrentReviewers(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  domain.ReviewRequest
+		status   domain.PRStatus
+		current  []string
+		wantSent *domain.ReviewRequest
+	}{
+		{
+			name:     "still assigned",
+			request:  domain.ReviewRequest{Reviewers: []string{"u2"}, Removed: []string{"u3"}},
+			status:   domain.PRStatusOpen,
+			current:  []string{"u2"},
+			wantSent: &domain.ReviewRequest{Reviewers: []string{"u2"}, Removed: []string{"u3"}},
+		},
+		{
+			name:     "reassigned since",
+			request:  domain.ReviewRequest{Reviewers: []string{"u2", "u4"}, Removed: []string{"u5"}},
+			status:   domain.PRStatusOpen,
+			current:  []string{"u4", "u5"},
+			wantSent: &domain.ReviewRequest{Reviewers: []string{"u4"}},
+		},
+		{
+			name:    "every change is out of date",
+			request: domain.ReviewRequest{Reviewers: []string{"u2"}, Removed: []string{"u4"}},
+			status:  domain.PRStatusOpen,
+			current: []string{"u4"},
+		},
+		{
+			name:    "pr merged",
+			request: domain.ReviewRequest{Reviewers: []string{"u2"}, Removed: []string{"u3"}},
+			status:  domain.PRStatusMerged,
+			current: []string{"u2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newReviewRequestFixture(t)
+			f.prs.prs["github-7"] = domain.PR{
+				ID:        "github-7",
+				Status:    tt.status,
+				Reviewers: tt.current,
+				Source:    &githubSource,
+			}
+			request := tt.request
+			request.PRID = "github-7"
+			request.Source = githubSource
+			if err := f.queue.EnqueueReviewRequest(context.Background(), request, domain.ReviewRequestAttempt{
+				Error:   "timeout",
+				RetryIn: time.Minute,
+			}); err != nil {
+				t.Fatalf("EnqueueReviewRequest: %v", err)
+			}
+
+			sent := f.retry(t)
+
+			requests := f.requester.Requests()
+			if tt.wantSent == nil {
+				if sent != 0 || len(requests) != 0 {
+					t.Errorf("sent %+v, want nothing", requests)
+				}
+			} else {
+				if sent != 1 || len(requests) != 1 {
+					t.Fatalf("sent %+v, want one request", requests)
+				}
+				if !slices.Equal(requests[0].Reviewers, tt.wantSent.Reviewers) ||
+					!slices.Equal(requests[0].Removed, tt.wantSent.Removed) {
+					t.Errorf("requested %+v, want %+v", requests[0], *tt.wantSent)
+				}
+			}
+			if len(f.queue.queued) != 0 {
+				t.Errorf("%d requests left in the queue, want none", len(f.queue.queued))
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+type selectionRequest struct {
+	trigger    string
+	prId       string
+	authorId   string
+	replacedId string
+	actorId    string
+	assigned   []string
+	members    []domain.Member
+	teamId     int
+	limit      int
+}
+
+// selectWithFallback selects reviewers from the team and, while slots remain
+// and the effective settings allow falling back, from its ancestors in turn.
+// Each team consulted gets its own decision.
+func (s *Service) selectWithFallback(
+	ctx context.Context,
+	req selectionRequest,
+	team *domain.Team,
+) ([]*domain.AssignmentDecision, []string, error) {
+	const op = "internal.service.reviewers.selectWithFallback"
+
+	chain, err := s.TeamProvider.GetTeamChain(ctx, team.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(chain) == 0 {
+		chain = []*domain.Team{team}
+	}
+
+	limit := req.limit
+	assigned := req.assigned
+
+	var (
+		decisions []*domain.AssignmentDecision
+		chosen    []string
+	)
+
+	current := team
+	for i := range chain {
+		if i > 0 {
+			current, err = s.TeamProvider.GetTeamById(ctx, chain[i].ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		req.members = current.Members
+		req.teamId = current.ID
+		req.assigned = append(slices.Clone(assigned), chosen...)
+		req.limit = limit - len(chosen)
+
+		decision, err := s.selectReviewers(ctx, req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		decisions = append(decisions, decision)
+		chosen = append(chosen, decision.Chosen...)
+
+		if len(chosen) >= limit || !domain.ResolveSettings(chain[i:]).FallbackToParent() {
+			break
+		}
+	}
+
+	return decisions, chosen, nil
+}
+
+// selectReviewers picks up to limit reviewers among the team members and
+// records every step of the decision. Candidates who are within their working
+// hours are preferred. When a pairing window is configured, candidates who
+// paired with the author often within the window are less likely to be picked.
+func (s *Service) selectReviewers(ctx context.Context, req selectionRequest) (*domain.AssignmentDecision, error) {
+	const op = "internal.service.reviewers.selectReviewers"
+
+	members := slices.Clone(req.members)
+	slices.SortFunc(members, func(a, b domain.Member) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	decision := &domain.AssignmentDecision{
+		PRID:       req.prId,
+		Trigger:    req.trigger,
+		Strategy:   domain.AssignmentStrategyUniform,
+		ActorID:    req.actorId,
+		Candidates: make([]string, 0, len(members)),
+		Seed:       rand.Int64(),
+		TeamID:     req.teamId,
+	}
+
+	for _, member := range members {
+		reason := exclusionReason(req, member)
+		if reason != "" {
+			decision.Exclusions = append(decision.Exclusions, domain.AssignmentExclusion{
+				UserID: member.UserID,
+				Reason: reason,
+			})
+
+			continue
+		}
+
+		decision.Candidates = append(decision.Candidates, member.UserID)
+	}
+
+	pairings := map[string]int{}
+	if window := s.reviewerConfig.PairingWindow(); window > 0 {
+		decision.Strategy = domain.AssignmentStrategyAntiAffinity
+
+		var err error
+		pairings, err = s.PRRepository.GetPairingCounts(ctx, req.authorId, time.Now().Add(-window))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	now := time.Now()
+	workingHours := make(map[string]domain.WorkingHours, len(members))
+	for _, member := range members {
+		workingHours[member.UserID] = member.WorkingHours
+	}
+
+	decision.Scores = make([]domain.AssignmentScore, len(decision.Candidates))
+	for i, candidate := range decision.Candidates {
+		decision.Scores[i] = domain.AssignmentScore{
+			UserID:         candidate,
+			Pairings:       pairings[candidate],
+			Weight:         pairingWeight(pairings[candidate]),
+			InWorkingHours: workingHours[candidate].Contains(now),
+		}
+	}
+
+	decision.Chosen = pickWeighted(decision.Scores, decision.Seed, req.limit)
+
+	return decision, nil
+}
+
+func exclusionReason(req selectionRequest, member domain.Member) string {
+	switch {
+	case member.UserID == req.authorId:
+		return domain.ExclusionReasonAuthor
+	case member.UserID == req.replacedId:
+		return domain.ExclusionReasonReplaced
+	case slices.Contains(req.assigned, member.UserID):
+		return domain.ExclusionReasonAlreadyAssigned
+	case !member.IsActive:
+		return domain.ExclusionReasonInactive
+	default:
+		return ""
+	}
+}
+
+// pickWeighted draws up to limit candidates without replacement, each with
+// its score weight. Candidates within working hours are drawn first and the
+// rest only fill the remaining slots. The draw depends only on the scores and
+// the seed, so a stored decision can be replayed.
+func pickWeighted(scores []domain.AssignmentScore, seed int64, limit int) []string {
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+
+	if limit <= 0 || limit > len(scores) {
+		limit = len(scores)
+	}
+
+	picked := make([]string, 0, limit)
+	for _, inWorkingHours := range []bool{true, false} {
+		pool := make([]domain.AssignmentScore, 0, len(scores))
+		for _, score := range scores {
+			if score.InWorkingHours == inWorkingHours {
+				pool = append(pool, score)
+			}
+		}
+
+		picked = drawWeighted(rng, pool, picked, limit)
+	}
+
+	return picked
+}
+
+func drawWeighted(rng *rand.Rand, pool []domain.AssignmentScore, picked []string, limit int) []string {
+	for len(picked) < limit && len(pool) > 0 {
+		total := 0.0
+		for _, score := range pool {
+			total += score.Weight
+		}
+
+		target := rng.Float64() * total
+		idx := len(pool) - 1
+		for i, score := range pool {
+			target -= score.Weight
+			if target < 0 {
+				idx = i
+				break
+			}
+		}
+
+		picked = append(picked, pool[idx].UserID)
+		pool = slices.Delete(pool, idx, idx+1)
+	}
+
+	return picked
+}
+
+// pairingWeight down-weights frequent partners without excluding them.
+func pairingWeight(pairings int) float64 {
+	return 1 / float64(1+pairings)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+func score(userId string, pairings int, inWorkingHours bool) domain.AssignmentScore {
+	return domain.AssignmentScore{
+		UserID:         userId,
+		Pairings:       pairings,
+		Weight:         pairingWeight(pairings),
+		InWorkingHours: inWorkingHours,
+	}
+}
+
+func TestPickWeightedReplaysFromSeed(t *testing.T) {
+	scores := []domain.AssignmentScore{
+		score("u1", 0, true),
+		score("u2", 2, true),
+		score("u3", 1, false),
+		score("u4", 0, true),
+		score("u5", 5, false),
+	}
+
+	tests := []struct {
+		name  string
+		seed  int64
+		limit int
+	}{
+		{name: "one reviewer", seed: 1, limit: 1},
+		{name: "two reviewers", seed: 42, limit: 2},
+		{name: "negative seed", seed: -7, limit: 3},
+		{name: "everyone", seed: 1 << 40, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := pickWeighted(slices.Clone(scores), tt.seed, tt.limit)
+			second := pickWeighted(slices.Clone(scores), tt.seed, tt.limit)
+			if !slices.Equal(first, second) {
+				t.Errorf("replay = %v, want %v", second, first)
+			}
+
+			want := tt.limit
+			if want == 0 {
+				want = len(scores)
+			}
+			if len(first) != want {
+				t.Errorf("picked %v, want %d reviewers", first, want)
+			}
+		})
+	}
+}
+
+func TestPickWeightedPrefersWorkingHours(t *testing.T) {
+	scores := []domain.AssignmentScore{
+		score("away1", 0, false),
+		score("here1", 9, true),
+		score("away2", 0, false),
+		score("here2", 9, true),
+	}
+	here := []string{"here1", "here2"}
+
+	for seed := range int64(200) {
+		picked := pickWeighted(scores, seed, 3)
+		if len(picked) != 3 {
+			t.Fatalf("seed %d: picked %v, want 3 reviewers", seed, picked)
+		}
+		if !slices.Contains(here, picked[0]) || !slices.Contains(here, picked[1]) {
+			t.Fatalf("seed %d: picked %v, want reviewers in working hours first", seed, picked)
+		}
+		if slices.Contains(here, picked[2]) {
+			t.Fatalf("seed %d: picked %v, want the last slot filled from outside working hours", seed, picked)
+		}
+	}
+}
+
+func TestPickWeightedDownWeightsPairings(t *testing.T) {
+	// fresh has weight 1 and frequent 1/(1+3), so fresh wins 80% of draws.
+	scores := []domain.AssignmentScore{
+		score("frequent", 3, true),
+		score("fresh", 0, true),
+	}
+
+	const draws = 5000
+	fresh := 0
+	for seed := range int64(draws) {
+		if pickWeighted(scores, seed, 1)[0] == "fresh" {
+			fresh++
+		}
+	}
+
+	if share := float64(fresh) / draws; share < 0.77 || share > 0.83 {
+		t.Errorf("fresh picked in %.3f of draws, want about 0.8", share)
+	}
+}
+
+func TestPairingWeight(t *testing.T) {
+	tests := []struct {
+		pairings int
+		want     float64
+	}{
+		{pairings: 0, want: 1},
+		{pairings: 1, want: 0.5},
+		{pairings: 3, want: 0.25},
+	}
+
+	for _, tt := range tests {
+		if got := pairingWeight(tt.pairings); got != tt.want {
+			t.Errorf("pairingWeight(%d) = %v, want %v", tt.pairings, got, tt.want)
+		}
+	}
+}
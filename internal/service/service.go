@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
@@ -13,50 +14,260 @@ var (
 	ErrTeamNotFound    = errors.New("team not found")
 	ErrUserNotFound    = errors.New("user not found")
 	ErrPRMerged        = errors.New("PR merged")
+	ErrPRClosed        = errors.New("PR closed")
 	ErrPRExists        = errors.New("PR exists")
 	ErrTeamExists      = errors.New("team already exists")
 	ErrNoCandidates    = errors.New("no candidates")
 	ErrUserNotReviewer = errors.New("user not reviewer")
+	ErrAlreadyReviewer = errors.New("new reviewer already assigned")
+	ErrInvalidDecision = errors.New("invalid review decision")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	ErrInvalidHours    = errors.New("invalid working hours")
+	ErrOpenReviews     = errors.New("users have open reviews")
+	ErrInvalidPolicy   = errors.New("invalid open reviews policy")
+	ErrAlreadyInTeam   = errors.New("user already in team")
+	ErrTeamRequired    = errors.New("user belongs to several teams, team is required")
+	ErrNotTeamMember   = errors.New("user is not a team member")
+	ErrParentNotFound  = errors.New("parent team not found")
+	ErrTeamCycle       = errors.New("team cannot be its own ancestor")
+	ErrTeamHasOpenPRs  = errors.New("team has open PRs and no parent team")
+	ErrInvalidSettings = errors.New("invalid team settings")
+	ErrInvalidEmail    = errors.New("invalid email")
+	ErrNoEmail         = errors.New("user has no email")
+	ErrDigestDisabled  = errors.New("email digest is not configured")
+
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrInvalidSubscription  = errors.New("invalid subscription")
+
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrInvalidRole   = errors.New("invalid role")
+	ErrTokenNotFound = errors.New("API token not found")
+	ErrInvalidToken  = errors.New("invalid API token")
+
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+	ErrInvalidPeriod      = errors.New("invalid period")
+	ErrInvalidBucket      = errors.New("invalid bucket")
+
+	// ErrReviewRequestRejected is returned by a ReviewRequester when the code
+	// host refuses the request; retrying it would fail the same way.
+	ErrReviewRequestRejected = errors.New("review request rejected")
+	// ErrNotificationRejected is returned by a Notifier when the chat refuses
+	// the notification; retrying it would fail the same way.
+	ErrNotificationRejected = errors.New("notification rejected")
 )
 
 type PRProvider interface {
-	Create(ctx context.Context, pr domain.PR) (*domain.PR, error)
+	Create(ctx context.Context, pr domain.PR, decisions []*domain.AssignmentDecision) (*domain.PR, error)
 	Get(ctx context.Context, prId string) (*domain.PR, error)
-	Merge(ctx context.Context, prId string) (*domain.PR, error)
+	Merge(ctx context.Context, prId string, mergedBy string) (*domain.PR, error)
+	ClosePR(ctx context.Context, prId string) (*domain.PR, error)
+	ReopenPR(ctx context.Context, prId string) (*domain.PR, error)
 	GetPullRequestsIdsByReviewer(ctx context.Context, reviewerId string) ([]string, error)
-	GetPRStatistics(ctx context.Context) (*domain.PRStatistics, error)
+	GetPRStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.PRStatistics, error)
+	GetStatisticsSeries(ctx context.Context, filter domain.StatisticsFilter) ([]domain.StatisticsPoint, error)
+	GetLatencyStatistics(ctx context.Context, filter domain.LatencyFilter) (*domain.LatencyStatistics, error)
+	GetPairingCounts(ctx context.Context, authorId string, since time.Time) (map[string]int, error)
+	GetAssignmentLog(ctx context.Context, prId string) ([]*domain.AssignmentDecision, error)
+	AddReview(ctx context.Context, prId string, reviewerId string, decision domain.ReviewDecision) error
+	GetPRTimeline(ctx context.Context, prId string) ([]*domain.PREvent, error)
 }
 
 type TeamProvider interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
 	GetTeamById(ctx context.Context, teamId int) (*domain.Team, error)
-	GetAllTeam(ctx context.Context) ([]*domain.Team, error)
+	GetTeamChain(ctx context.Context, teamId int) ([]*domain.Team, error)
+	GetTeamTree(ctx context.Context) ([]*domain.TeamNode, error)
+	GetAllTeam(ctx context.Context, filter domain.TeamFilter) (*domain.TeamPage, error)
+	UpdateTeam(ctx context.Context, teamName string, update domain.TeamUpdate) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, teamName string, policy domain.OpenReviewsPolicy) (*domain.Team, error)
 	GetTeamStatistics(ctx context.Context) (*domain.TeamStatistics, error)
 }
 
 type UserProvider interface {
 	SetIsActive(ctx context.Context, userId string, isActive bool) (*domain.User, error)
-	GetReview(ctx context.Context, prIds []string) ([]*domain.PRShort, error)
+	SetRole(ctx context.Context, userId string, role domain.Role) (*domain.User, error)
+	SetTeamRole(ctx context.Context, userId string, teamId int, role domain.Role) (*domain.User, error)
+	GetReview(ctx context.Context, reviewerId string) ([]*domain.PRShort, error)
+	SetWorkingHours(ctx context.Context, userId string, workingHours domain.WorkingHours) (*domain.User, error)
+	MoveUser(
+		ctx context.Context,
+		userId string,
+		fromTeamId int,
+		toTeamId int,
+		policy domain.OpenReviewsPolicy,
+		replacements []domain.ReviewerReplacement,
+	) error
 	GetUser(ctx context.Context, userId string) (*domain.User, error)
-	GetReviewers(ctx context.Context, teamId int, excludeUserIds []string, limit int) ([]string, error)
-	ReplaceReviewer(ctx context.Context, newReviewerId string, oldReviewerId string, prId string) error
-	GetUserStatistics(ctx context.Context) (*domain.UserStatistics, error)
-	GetUserAssignmentStatistics(ctx context.Context) ([]domain.UserAssignmentStat, error)
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error)
+	ReplaceReviewer(ctx context.Context, replacement domain.ReviewerReplacement) error
+	SetEmail(ctx context.Context, userId string, settings domain.EmailSettings) (*domain.User, error)
+	GetDigestRecipients(ctx context.Context) ([]*domain.DigestRecipient, error)
+	MarkDigestSent(ctx context.Context, userId string) error
+	GetUserStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.UserStatistics, error)
+	GetUserAssignmentStatistics(
+		ctx context.Context,
+		filter domain.StatisticsFilter,
+	) ([]domain.UserAssignmentStat, error)
+}
+
+type WebhookProvider interface {
+	ClaimDelivery(ctx context.Context, provider string, deliveryId string) (bool, error)
+	ReleaseDelivery(ctx context.Context, provider string, deliveryId string) error
+}
+
+type ReviewRequestProvider interface {
+	EnqueueReviewRequest(ctx context.Context, request domain.ReviewRequest, attempt domain.ReviewRequestAttempt) error
+	ClaimReviewRequests(ctx context.Context, limit int, lease time.Duration) ([]*domain.QueuedReviewRequest, error)
+	UpdateReviewRequest(ctx context.Context, id int64, attempt domain.ReviewRequestAttempt) error
+	DeleteReviewRequest(ctx context.Context, id int64) error
+}
+
+type SubscriptionProvider interface {
+	CreateSubscription(ctx context.Context, subscription domain.Subscription) (*domain.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*domain.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	GetSubscriptionDeliveries(ctx context.Context, subscriptionId int64, limit int) ([]*domain.SubscriptionDelivery, error)
+	FanOutOutboxEvents(ctx context.Context, limit int) (int, error)
+	ClaimSubscriptionDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.SubscriptionDelivery, error)
+	CompleteSubscriptionDelivery(ctx context.Context, id int64, statusCode int) error
+	UpdateSubscriptionDelivery(ctx context.Context, id int64, attempt domain.DeliveryAttempt) error
+}
+
+type NotificationProvider interface {
+	EnqueueNotifications(ctx context.Context, notifications []domain.Notification) error
+	EnqueueOverdueNotification(ctx context.Context, notification domain.Notification) error
+	GetUnreportedReviews(ctx context.Context) ([]*domain.OpenReview, error)
+	ClaimNotifications(ctx context.Context, limit int, lease time.Duration) ([]*domain.QueuedNotification, error)
+	UpdateNotification(ctx context.Context, id int64, attempt domain.NotificationAttempt) error
+	DeleteNotification(ctx context.Context, id int64) error
+}
+
+// Notifier posts a notification to the reviewer in a chat. Notifications are
+// disabled when the service has no notifier.
+type Notifier interface {
+	Notify(ctx context.Context, notification domain.Notification) error
+}
+
+type TokenProvider interface {
+	CreateAPIToken(ctx context.Context, token domain.APIToken, hash string) (*domain.APIToken, error)
+	EnsureAPIToken(ctx context.Context, token domain.APIToken, hash string) error
+	ListAPITokens(ctx context.Context) ([]*domain.APIToken, error)
+	RevokeAPIToken(ctx context.Context, id int64) (*domain.APIToken, error)
+	UseAPIToken(ctx context.Context, hash string) (*domain.APIToken, error)
+}
+
+type AuditProvider interface {
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error)
+}
+
+// JWTVerifier validates JWTs from the OIDC identity provider. JWTs are
+// rejected when the service has no verifier.
+type JWTVerifier interface {
+	// VerifyJWT returns the token subject, a user ID.
+	VerifyJWT(ctx context.Context, token string) (string, error)
+}
+
+// Mailer emails the review digest. Digests are disabled when the service has
+// no mailer.
+type Mailer interface {
+	SendDigest(ctx context.Context, digest domain.Digest) error
+}
+
+// EventSender delivers an event to a subscriber. It returns the response
+// status code, zero when no response was received.
+type EventSender interface {
+	Send(ctx context.Context, delivery *domain.SubscriptionDelivery) (int, error)
+}
+
+// ReviewRequester pushes assigned reviewers back to the code host a PR was
+// registered from.
+type ReviewRequester interface {
+	// Provider is the VCS provider the requester talks to, as in
+	// domain.PRSource.
+	Provider() string
+	RequestReviewers(ctx context.Context, request domain.ReviewRequest) error
+}
+
+// ReviewerConfig holds the global reviewer selection settings.
+type ReviewerConfig interface {
+	// PairingWindow is how far back reviewer/author pairings are counted.
+	// Zero disables the anti-affinity weighting.
+	PairingWindow() time.Duration
+	// ReviewSLA is how many of the reviewer's business hours a review may
+	// wait before it is overdue. Zero disables the SLA.
+	ReviewSLA() time.Duration
 }
 
 type Service struct {
-	log          *slog.Logger
-	PRRepository PRProvider
-	TeamProvider TeamProvider
-	UserProvider UserProvider
+	log                   *slog.Logger
+	PRRepository          PRProvider
+	TeamProvider          TeamProvider
+	UserProvider          UserProvider
+	WebhookProvider       WebhookProvider
+	ReviewRequestProvider ReviewRequestProvider
+	SubscriptionProvider  SubscriptionProvider
+	NotificationProvider  NotificationProvider
+	TokenProvider         TokenProvider
+	AuditProvider         AuditProvider
+	reviewRequesters      map[string]ReviewRequester
+	eventSender           EventSender
+	notifier              Notifier
+	mailer                Mailer
+	jwtVerifier           JWTVerifier
+	reviewerConfig        ReviewerConfig
+	now                   func() time.Time
+}
+
+// Providers are the storages the service reads and writes. The postgres
+// storage implements all of them.
+type Providers struct {
+	PR            PRProvider
+	Team          TeamProvider
+	User          UserProvider
+	Webhook       WebhookProvider
+	ReviewRequest ReviewRequestProvider
+	Subscription  SubscriptionProvider
+	Notification  NotificationProvider
+	Token         TokenProvider
+	Audit         AuditProvider
 }
 
-func New(log *slog.Logger, prProvider PRProvider, teamProvider TeamProvider, userProvider UserProvider) *Service {
+// Clients are the external systems the service talks to. A nil client
+// disables what depends on it.
+type Clients struct {
+	ReviewRequesters []ReviewRequester
+	EventSender      EventSender
+	Notifier         Notifier
+	Mailer           Mailer
+	JWTVerifier      JWTVerifier
+}
+
+func New(log *slog.Logger, providers Providers, clients Clients, reviewerConfig ReviewerConfig) *Service {
+	requesters := make(map[string]ReviewRequester, len(clients.ReviewRequesters))
+	for _, requester := range clients.ReviewRequesters {
+		requesters[requester.Provider()] = requester
+	}
+
 	return &Service{
-		log:          log,
-		PRRepository: prProvider,
-		TeamProvider: teamProvider,
-		UserProvider: userProvider,
+		log:                   log,
+		PRRepository:          providers.PR,
+		TeamProvider:          providers.Team,
+		UserProvider:          providers.User,
+		WebhookProvider:       providers.Webhook,
+		ReviewRequestProvider: providers.ReviewRequest,
+		reviewRequesters:      requesters,
+		SubscriptionProvider:  providers.Subscription,
+		eventSender:           clients.EventSender,
+		NotificationProvider:  providers.Notification,
+		TokenProvider:         providers.Token,
+		AuditProvider:         providers.Audit,
+		notifier:              clients.Notifier,
+		mailer:                clients.Mailer,
+		jwtVerifier:           clients.JWTVerifier,
+		reviewerConfig:        reviewerConfig,
+		now:                   time.Now,
 	}
 }
@@ -2,26 +2,69 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
 	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
-func (s *Service) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
+// GetStatistics returns PR, user and assignment counts narrowed to the
+// filter's team and period, along with a series bucketed by day or week. The
+// counts cover all time unless the period is bounded; the series ends now and
+// spans 30 days unless bounded.
+func (s *Service) GetStatistics(ctx context.Context, filter domain.StatisticsFilter) (*domain.Statistics, error) {
 	const op = "internal.service.statistics.GetStatistics"
 
 	log := s.log.With(
-		slog.String("op", op))
+		slog.String("op", op),
+		slog.String("teamName", filter.TeamName))
+
+	switch filter.Bucket {
+	case "":
+		filter.Bucket = domain.StatisticsBucketDay
+	case domain.StatisticsBucketDay, domain.StatisticsBucketWeek:
+	default:
+		log.Warn("invalid bucket")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidBucket)
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		log.Warn("invalid period")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPeriod)
+	}
+
+	seriesFilter, err := statisticsSeriesFilter(filter)
+	if err != nil {
+		log.Warn("invalid period", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if filter.TeamName != "" {
+		team, err := s.TeamProvider.GetTeam(ctx, filter.TeamName)
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			log.Warn("team not found")
+			return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+		}
+		if err != nil {
+			log.Error("failed to get team", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		filter.TeamID = team.ID
+		seriesFilter.TeamID = team.ID
+	}
 
-	prStatistics, err := s.PRRepository.GetPRStatistics(ctx)
+	log.Info("attempting to get statistics")
+	prStatistics, err := s.PRRepository.GetPRStatistics(ctx, filter)
 	if err != nil {
 		log.Error("failed to get pr statistics", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	userStatistics, err := s.UserProvider.GetUserStatistics(ctx)
+	userStatistics, err := s.UserProvider.GetUserStatistics(ctx, filter)
 	if err != nil {
 		log.Error("failed to get user statistics", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -33,14 +76,22 @@ func (s *Service) GetStatistics(ctx context.Context) (*domain.Statistics, error)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	userAssignmentsStat, err := s.UserProvider.GetUserAssignmentStatistics(ctx)
+	userAssignmentsStat, err := s.UserProvider.GetUserAssignmentStatistics(ctx, filter)
 	if err != nil {
 		log.Error("failed to get user assignment statistics", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	series, err := s.PRRepository.GetStatisticsSeries(ctx, seriesFilter)
+	if err != nil {
+		log.Error("failed to get statistics series", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	statistics := domain.Statistics{
 		UserAssignments: userAssignmentsStat,
+		Series:          series,
+		Bucket:          filter.Bucket,
 		TotalPRs:        prStatistics.TotalPRs,
 		OpenPRs:         prStatistics.OpenPRs,
 		MergedPRs:       prStatistics.MergedPRs,
@@ -49,5 +100,139 @@ func (s *Service) GetStatistics(ctx context.Context) (*domain.Statistics, error)
 		ActiveUsers:     userStatistics.ActiveUsers,
 	}
 
+	log.Info("successfully got statistics")
 	return &statistics, nil
 }
+
+// maxStatisticsBuckets caps the series at about a year of days.
+const maxStatisticsBuckets = 366
+
+// statisticsSeriesFilter bounds the filter's period for the series: it ends
+// now and spans 30 days unless bounded.
+func statisticsSeriesFilter(filter domain.StatisticsFilter) (domain.StatisticsFilter, error) {
+	to := time.Now()
+	if filter.To != nil {
+		to = *filter.To
+	}
+	from := to.Add(-defaultStatisticsPeriod)
+	if filter.From != nil {
+		from = *filter.From
+	}
+	if !from.Before(to) {
+		return filter, ErrInvalidPeriod
+	}
+
+	bucket := 24 * time.Hour
+	if filter.Bucket == domain.StatisticsBucketWeek {
+		bucket *= 7
+	}
+	if to.Sub(from)/bucket >= maxStatisticsBuckets {
+		return filter, ErrInvalidPeriod
+	}
+
+	filter.From = &from
+	filter.To = &to
+	return filter, nil
+}
+
+// defaultStatisticsPeriod is the period statistics cover when the request
+// does not bound it.
+const defaultStatisticsPeriod = 30 * 24 * time.Hour
+
+// GetLatencyStatistics returns time-to-merge, time-to-first-review and the
+// reassignment rate over the period. The period ends now and spans 30 days
+// unless bounded.
+func (s *Service) GetLatencyStatistics(ctx context.Context, filter domain.LatencyFilter) (*domain.LatencyStatistics, error) {
+	const op = "internal.service.statistics.GetLatencyStatistics"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultStatisticsPeriod)
+	}
+	if !filter.From.Before(filter.To) {
+		log.Warn("invalid period")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPeriod)
+	}
+
+	log.Info("attempting to get latency statistics")
+	statistics, err := s.PRRepository.GetLatencyStatistics(ctx, filter)
+	if err != nil {
+		log.Error("failed to get latency statistics", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully got latency statistics")
+	return statistics, nil
+}
+
+// GetFairnessReport returns how evenly each team's PRs assigned within the
+// period were spread over its members. The period ends now and spans 30 days
+// unless bounded.
+func (s *Service) GetFairnessReport(ctx context.Context, filter domain.FairnessFilter) (*domain.FairnessReport, error) {
+	const op = "internal.service.statistics.GetFairnessReport"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", filter.TeamName))
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultStatisticsPeriod)
+	}
+	if !filter.From.Before(filter.To) {
+		log.Warn("invalid period")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPeriod)
+	}
+
+	var teams []*domain.TeamNode
+	if filter.TeamName != "" {
+		team, err := s.TeamProvider.GetTeam(ctx, filter.TeamName)
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			log.Warn("team not found")
+			return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+		}
+		if err != nil {
+			log.Error("failed to get team", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		teams = []*domain.TeamNode{{ID: team.ID, Name: team.Name}}
+	} else {
+		var err error
+		teams, err = s.TeamProvider.GetTeamTree(ctx)
+		if err != nil {
+			log.Error("failed to get teams", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Info("attempting to get fairness report")
+	report := domain.FairnessReport{
+		From:  filter.From,
+		To:    filter.To,
+		Teams: make([]domain.TeamFairness, 0, len(teams)),
+	}
+	for _, team := range teams {
+		stats, err := s.UserProvider.GetUserAssignmentStatistics(ctx, domain.StatisticsFilter{
+			From:        &filter.From,
+			To:          &filter.To,
+			TeamID:      team.ID,
+			IncludeIdle: true,
+		})
+		if err != nil {
+			log.Error("failed to get user assignment statistics", sl.Err(err), slog.String("team", team.Name))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		report.Teams = append(report.Teams, domain.NewTeamFairness(team.ID, team.Name, stats))
+	}
+
+	log.Info("successfully got fairness report", slog.Int("teams", len(report.Teams)))
+	return &report, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	subscriptionDeliveriesLimit = 100
+
+	outboxBatch                 = 100
+	deliveryBatch               = 50
+	deliveryLease               = 5 * time.Minute
+	deliveryMaxAttempts         = 8
+	deliveryBaseDelay           = 30 * time.Second
+	deliveryMaxDelay            = time.Hour
+	subscriptionSecretMinLength = 16
+)
+
+func (s *Service) CreateSubscription(ctx context.Context, subscription domain.Subscription) (*domain.Subscription, error) {
+	const op = "internal.service.subscription.CreateSubscription"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("url", subscription.URL))
+
+	if err := validateSubscription(&subscription); err != nil {
+		log.Warn("invalid subscription", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to create subscription")
+	created, err := s.SubscriptionProvider.CreateSubscription(ctx, subscription)
+	if err != nil {
+		log.Error("failed to create subscription", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully created subscription", slog.Int64("subscriptionId", created.ID))
+	return created, nil
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]*domain.Subscription, error) {
+	const op = "internal.service.subscription.ListSubscriptions"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	log.Info("attempting to list subscriptions")
+	subscriptions, err := s.SubscriptionProvider.ListSubscriptions(ctx)
+	if err != nil {
+		log.Error("failed to list subscriptions", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully listed subscriptions", slog.Int("count", len(subscriptions)))
+	return subscriptions, nil
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id int64) error {
+	const op = "internal.service.subscription.DeleteSubscription"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("subscriptionId", id))
+
+	log.Info("attempting to delete subscription")
+	err := s.SubscriptionProvider.DeleteSubscription(ctx, id)
+	if errors.Is(err, repository.ErrSubscriptionNotFound) {
+		log.Warn("subscription not found")
+		return fmt.Errorf("%s: %w", op, ErrSubscriptionNotFound)
+	}
+	if err != nil {
+		log.Error("failed to delete subscription", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully deleted subscription")
+	return nil
+}
+
+// GetSubscriptionDeliveries returns the latest deliveries to the
+// subscription, newest first.
+func (s *Service) GetSubscriptionDeliveries(ctx context.Context, id int64) ([]*domain.SubscriptionDelivery, error) {
+	const op = "internal.service.subscription.GetSubscriptionDeliveries"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("subscriptionId", id))
+
+	log.Info("attempting to get subscription")
+	_, err := s.SubscriptionProvider.GetSubscription(ctx, id)
+	if errors.Is(err, repository.ErrSubscriptionNotFound) {
+		log.Warn("subscription not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrSubscriptionNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get subscription", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to get deliveries")
+	deliveries, err := s.SubscriptionProvider.GetSubscriptionDeliveries(ctx, id, subscriptionDeliveriesLimit)
+	if err != nil {
+		log.Error("failed to get deliveries", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully got deliveries")
+	return deliveries, nil
+}
+
+// DispatchEvents turns new outbox events into deliveries for their
+// subscribers and sends the deliveries that are due. It reports how many
+// deliveries succeeded. Failed deliveries back off exponentially from 30
+// seconds, capped at an hour, and are given up after deliveryMaxAttempts
+// attempts.
+func (s *Service) DispatchEvents(ctx context.Context) (int, error) {
+	const op = "internal.service.subscription.DispatchEvents"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	events, err := s.SubscriptionProvider.FanOutOutboxEvents(ctx, outboxBatch)
+	if err != nil {
+		log.Error("failed to fan out outbox events", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if events > 0 {
+		log.Info("dispatched outbox events", slog.Int("count", events))
+	}
+
+	deliveries, err := s.SubscriptionProvider.ClaimSubscriptionDeliveries(ctx, deliveryBatch, deliveryLease)
+	if err != nil {
+		log.Error("failed to claim deliveries", sl.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		log := log.With(
+			slog.Int64("deliveryId", delivery.ID),
+			slog.Int64("subscriptionId", delivery.Subscription.ID),
+			slog.String("eventType", string(delivery.Event.Type)),
+			slog.Int("attempt", delivery.Attempts+1))
+
+		statusCode, err := s.eventSender.Send(ctx, delivery)
+		if err == nil {
+			if err := s.SubscriptionProvider.CompleteSubscriptionDelivery(ctx, delivery.ID, statusCode); err != nil {
+				log.Error("failed to complete delivery", sl.Err(err))
+				return delivered, fmt.Errorf("%s: %w", op, err)
+			}
+
+			log.Info("successfully delivered event")
+			delivered++
+			continue
+		}
+
+		attempt := nextDeliveryAttempt(err, statusCode, delivery.Attempts+1)
+		if attempt.GiveUp {
+			log.Error("giving up delivery", sl.Err(err))
+		} else {
+			log.Warn("failed to deliver event", sl.Err(err), slog.Duration("retryIn", attempt.RetryIn))
+		}
+
+		if err := s.SubscriptionProvider.UpdateSubscriptionDelivery(ctx, delivery.ID, attempt); err != nil {
+			log.Error("failed to update delivery", sl.Err(err))
+			return delivered, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return delivered, nil
+}
+
+func nextDeliveryAttempt(err error, statusCode int, attempts int) domain.DeliveryAttempt {
+	delay := deliveryMaxDelay
+	if shift := attempts - 1; shift < 16 {
+		delay = min(deliveryBaseDelay<<shift, deliveryMaxDelay)
+	}
+
+	return domain.DeliveryAttempt{
+		Error:      err.Error(),
+		StatusCode: statusCode,
+		RetryIn:    delay,
+		GiveUp:     attempts >= deliveryMaxAttempts,
+	}
+}
+
+// validateSubscription requires an absolute http(s) URL, a secret long
+// enough to sign with and known event types, which it deduplicates.
+func validateSubscription(subscription *domain.Subscription) error {
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidSubscription)
+	}
+	if len(subscription.Secret) < subscriptionSecretMinLength {
+		return fmt.Errorf("%w: secret must be at least %d characters", ErrInvalidSubscription, subscriptionSecretMinLength)
+	}
+	if len(subscription.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+
+	eventTypes := make([]domain.EventType, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		if !eventType.Valid() {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidSubscription, eventType)
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	subscription.EventTypes = eventTypes
+
+	return nil
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
 	"github.com/moremoneymod/pr-reviewer/internal/repository"
 	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
+// Create adds the team and its members. Only admins may create teams.
 func (s *Service) Create(ctx context.Context, team *domain.Team) (*domain.Team, error) {
 	const op = "internal.service.team.Create"
 
@@ -18,17 +20,35 @@ func (s *Service) Create(ctx context.Context, team *domain.Team) (*domain.Team,
 		slog.String("op", op),
 		slog.String("teamName", team.Name))
 
+	if err := validateTeamSettings(team.Settings); err != nil {
+		log.Warn("invalid team settings")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.authorizeAdmin(ctx); err != nil {
+		log.Warn("caller may not create teams", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Info("attempting to create new team")
 	teamEntity, err := s.TeamProvider.CreateTeam(ctx, team)
 	if errors.Is(err, repository.ErrTeamExists) {
 		log.Warn("team already exists")
 		return nil, fmt.Errorf("%s: %w", op, ErrTeamExists)
 	}
+	if errors.Is(err, repository.ErrParentNotFound) {
+		log.Warn("parent team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrParentNotFound)
+	}
 	if err != nil {
 		log.Error("failed to create team", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if len(teamEntity.ExistingUsers) > 0 {
+		log.Warn("existing users joined the team unchanged", slog.Any("userIds", teamEntity.ExistingUsers))
+	}
+
 	log.Info("successfully created team")
 	return teamEntity, nil
 }
@@ -51,6 +71,246 @@ func (s *Service) Get(ctx context.Context, teamName string) (*domain.Team, error
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	log.Info("attempting to resolve team settings")
+	chain, err := s.TeamProvider.GetTeamChain(ctx, team.ID)
+	if err != nil {
+		log.Error("failed to get team chain", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	team.Effective = s.effectiveSettings(chain)
+
 	log.Info("successfully got team")
 	return team, nil
 }
+
+const (
+	defaultTeamPageLimit = 50
+	maxTeamPageLimit     = 100
+)
+
+// ListTeams returns a page of teams. The limit defaults to 50 and is capped
+// at 100.
+func (s *Service) ListTeams(ctx context.Context, filter domain.TeamFilter) (*domain.TeamPage, error) {
+	const op = "internal.service.team.ListTeams"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTeamPageLimit
+	}
+	filter.Limit = min(filter.Limit, maxTeamPageLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	log.Info("attempting to list teams")
+	page, err := s.TeamProvider.GetAllTeam(ctx, filter)
+	if err != nil {
+		log.Error("failed to list teams", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully listed teams", slog.Int("total", page.Total))
+	return page, nil
+}
+
+// GetTree returns the team hierarchy as a forest of top-level teams.
+func (s *Service) GetTree(ctx context.Context) ([]*domain.TeamNode, error) {
+	const op = "internal.service.team.GetTree"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	log.Info("attempting to get team tree")
+	nodes, err := s.TeamProvider.GetTeamTree(ctx)
+	if err != nil {
+		log.Error("failed to get team tree", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byId := make(map[int]*domain.TeamNode, len(nodes))
+	for _, node := range nodes {
+		byId[node.ID] = node
+	}
+
+	roots := make([]*domain.TeamNode, 0)
+	for _, node := range nodes {
+		parent, ok := byId[node.ParentID]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	log.Info("successfully got team tree")
+	return roots, nil
+}
+
+// Update changes the team's name, parent, settings and members. Users must be
+// admins or leads of the team.
+func (s *Service) Update(ctx context.Context, teamName string, update domain.TeamUpdate) (*domain.Team, error) {
+	const op = "internal.service.team.Update"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName))
+
+	policy, err := normalizeOpenReviewsPolicy(update.OpenReviewsPolicy)
+	if err != nil {
+		log.Warn("invalid open reviews policy")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	update.OpenReviewsPolicy = policy
+
+	if update.Settings != nil {
+		if err := validateTeamSettings(*update.Settings); err != nil {
+			log.Warn("invalid team settings")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := s.authorizeTeam(ctx, teamName); err != nil {
+		log.Warn("caller may not update team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to update team")
+	team, err := s.TeamProvider.UpdateTeam(ctx, teamName, update)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		log.Warn("team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if errors.Is(err, repository.ErrTeamExists) {
+		log.Warn("team already exists")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamExists)
+	}
+	if errors.Is(err, repository.ErrOpenReviews) {
+		log.Warn("removed members have open reviews")
+		return nil, fmt.Errorf("%s: %w", op, ErrOpenReviews)
+	}
+	if errors.Is(err, repository.ErrNotTeamMember) {
+		log.Warn("removed user is not a team member")
+		return nil, fmt.Errorf("%s: %w", op, ErrNotTeamMember)
+	}
+	if errors.Is(err, repository.ErrParentNotFound) {
+		log.Warn("parent team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrParentNotFound)
+	}
+	if errors.Is(err, repository.ErrTeamCycle) {
+		log.Warn("parent team is a descendant of the team")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamCycle)
+	}
+	if err != nil {
+		log.Error("failed to update team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(team.ExistingUsers) > 0 {
+		log.Warn("existing users joined the team unchanged", slog.Any("userIds", team.ExistingUsers))
+	}
+
+	log.Info("successfully updated team")
+	return team, nil
+}
+
+// Delete removes the team. Users must be admins or leads of the team. Its
+// open PRs move to the parent team; without one the team must have none.
+func (s *Service) Delete(ctx context.Context, teamName string, policy domain.OpenReviewsPolicy) (*domain.Team, error) {
+	const op = "internal.service.team.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName))
+
+	policy, err := normalizeOpenReviewsPolicy(policy)
+	if err != nil {
+		log.Warn("invalid open reviews policy")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.authorizeTeam(ctx, teamName); err != nil {
+		log.Warn("caller may not delete team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to delete team")
+	team, err := s.TeamProvider.DeleteTeam(ctx, teamName, policy)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		log.Warn("team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if errors.Is(err, repository.ErrOpenReviews) {
+		log.Warn("team members have open reviews")
+		return nil, fmt.Errorf("%s: %w", op, ErrOpenReviews)
+	}
+	if errors.Is(err, repository.ErrTeamHasOpenPRs) {
+		log.Warn("team has open PRs and no parent team")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamHasOpenPRs)
+	}
+	if err != nil {
+		log.Error("failed to delete team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully deleted team")
+	return team, nil
+}
+
+// normalizeOpenReviewsPolicy defaults to rejecting the change when members
+// still have open reviews.
+func normalizeOpenReviewsPolicy(policy domain.OpenReviewsPolicy) (domain.OpenReviewsPolicy, error) {
+	switch policy {
+	case "":
+		return domain.OpenReviewsReject, nil
+	case domain.OpenReviewsReject, domain.OpenReviewsUnassign, domain.OpenReviewsKeep:
+		return policy, nil
+	default:
+		return "", ErrInvalidPolicy
+	}
+}
+
+func validateTeamSettings(settings domain.TeamSettings) error {
+	if settings.ReviewSLA != nil && *settings.ReviewSLA < time.Minute {
+		return ErrInvalidSettings
+	}
+	if settings.ReviewerFallback != nil {
+		switch *settings.ReviewerFallback {
+		case domain.ReviewerFallbackNone, domain.ReviewerFallbackParent:
+		default:
+			return ErrInvalidSettings
+		}
+	}
+
+	return nil
+}
+
+// effectiveSettings resolves the settings of the first team in the chain.
+// Settings unset along the whole chain come from the config: the global
+// review SLA and no reviewer fallback.
+func (s *Service) effectiveSettings(chain []*domain.Team) domain.TeamSettings {
+	reviewSLA := s.reviewerConfig.ReviewSLA()
+	fallback := domain.ReviewerFallbackNone
+
+	return domain.ResolveSettings(chain).Inherit(domain.TeamSettings{
+		ReviewSLA:        &reviewSLA,
+		ReviewerFallback: &fallback,
+	})
+}
+
+// teamReviewSLA returns the effective review SLA of the team, or the global
+// one for PRs without a team.
+func (s *Service) teamReviewSLA(ctx context.Context, teamId int) (time.Duration, error) {
+	const op = "internal.service.team.teamReviewSLA"
+
+	if teamId == 0 {
+		return s.reviewerConfig.ReviewSLA(), nil
+	}
+
+	chain, err := s.TeamProvider.GetTeamChain(ctx, teamId)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return *s.effectiveSettings(chain).ReviewSLA, nil
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/auth"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/repository"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const (
+	tokenNameMaxLength      = 255
+	bootstrapTokenName      = "bootstrap"
+	bootstrapTokenMinLength = 16
+)
+
+// CreateAPIToken issues a token with the scopes. The returned token carries
+// the plain token, which cannot be recovered later.
+func (s *Service) CreateAPIToken(ctx context.Context, name string, scopes []domain.Scope) (*domain.APIToken, error) {
+	const op = "internal.service.token.CreateAPIToken"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("tokenName", name))
+
+	token := domain.APIToken{Name: strings.TrimSpace(name), Scopes: scopes}
+	if err := validateAPIToken(&token); err != nil {
+		log.Warn("invalid token", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	plain, err := auth.GenerateToken()
+	if err != nil {
+		log.Error("failed to generate token", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	token.Prefix = auth.DisplayPrefix(plain)
+
+	log.Info("attempting to create token")
+	created, err := s.TokenProvider.CreateAPIToken(ctx, token, auth.HashToken(plain))
+	if err != nil {
+		log.Error("failed to create token", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	created.Token = plain
+
+	log.Info("successfully created token", slog.Int64("tokenId", created.ID))
+	return created, nil
+}
+
+// EnsureBootstrapToken registers a configured admin token, so that the
+// first real tokens can be created. A revoked bootstrap token stays
+// revoked; configure a different one to replace it.
+func (s *Service) EnsureBootstrapToken(ctx context.Context, plain string) error {
+	const op = "internal.service.token.EnsureBootstrapToken"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	if len(plain) < bootstrapTokenMinLength {
+		log.Warn("bootstrap token is too short")
+		return fmt.Errorf("%s: %w: bootstrap token must be at least %d characters",
+			op, ErrInvalidToken, bootstrapTokenMinLength)
+	}
+
+	token := domain.APIToken{
+		Name:   bootstrapTokenName,
+		Prefix: auth.DisplayPrefix(plain),
+		Scopes: []domain.Scope{domain.ScopeAdmin},
+	}
+
+	log.Info("attempting to register bootstrap token")
+	err := s.TokenProvider.EnsureAPIToken(ctx, token, auth.HashToken(plain))
+	if err != nil {
+		log.Error("failed to register bootstrap token", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully registered bootstrap token")
+	return nil
+}
+
+func (s *Service) ListAPITokens(ctx context.Context) ([]*domain.APIToken, error) {
+	const op = "internal.service.token.ListAPITokens"
+
+	log := s.log.With(
+		slog.String("op", op))
+
+	log.Info("attempting to list tokens")
+	tokens, err := s.TokenProvider.ListAPITokens(ctx)
+	if err != nil {
+		log.Error("failed to list tokens", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully listed tokens", slog.Int("count", len(tokens)))
+	return tokens, nil
+}
+
+func (s *Service) RevokeAPIToken(ctx context.Context, id int64) (*domain.APIToken, error) {
+	const op = "internal.service.token.RevokeAPIToken"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("tokenId", id))
+
+	log.Info("attempting to revoke token")
+	token, err := s.TokenProvider.RevokeAPIToken(ctx, id)
+	if errors.Is(err, repository.ErrTokenNotFound) {
+		log.Warn("token not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTokenNotFound)
+	}
+	if err != nil {
+		log.Error("failed to revoke token", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully revoked token")
+	return token, nil
+}
+
+// Authenticate resolves the caller presenting the bearer token: an API token
+// or an OIDC JWT. Unknown, revoked and invalid tokens are ErrUnauthorized.
+func (s *Service) Authenticate(ctx context.Context, bearer string) (*domain.Principal, error) {
+	const op = "internal.service.token.Authenticate"
+
+	if !strings.HasPrefix(bearer, auth.TokenPrefix) && strings.Count(bearer, ".") == 2 {
+		principal, err := s.authenticateUser(ctx, bearer)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return principal, nil
+	}
+
+	token, err := s.TokenProvider.UseAPIToken(ctx, auth.HashToken(bearer))
+	if errors.Is(err, repository.ErrTokenNotFound) {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &domain.Principal{
+		Name:    token.Name,
+		Scopes:  token.Scopes,
+		TokenID: token.ID,
+	}, nil
+}
+
+// authenticateUser resolves a person signed in through OIDC. The JWT
+// subject must be the ID of an existing, active user. Admins get the admin
+// scope and everyone else the write scope; roles decide the rest.
+func (s *Service) authenticateUser(ctx context.Context, jwt string) (*domain.Principal, error) {
+	const op = "internal.service.token.authenticateUser"
+
+	if s.jwtVerifier == nil {
+		return nil, fmt.Errorf("%s: %w: JWTs are not accepted", op, ErrUnauthorized)
+	}
+
+	userId, err := s.jwtVerifier.VerifyJWT(ctx, jwt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, fmt.Errorf("%s: %w: unknown user %q", op, ErrUnauthorized, userId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("%s: %w: user %q is inactive", op, ErrUnauthorized, userId)
+	}
+
+	scope := domain.ScopeWrite
+	if user.Role == domain.RoleAdmin {
+		scope = domain.ScopeAdmin
+	}
+
+	return &domain.Principal{
+		Name:   user.Username,
+		UserID: user.ID,
+		Scopes: []domain.Scope{scope},
+	}, nil
+}
+
+// validateAPIToken requires a name and known scopes, which it
+// deduplicates.
+func validateAPIToken(token *domain.APIToken) error {
+	if token.Name == "" || len(token.Name) > tokenNameMaxLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidToken, tokenNameMaxLength)
+	}
+	if len(token.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidToken)
+	}
+
+	scopes := make([]domain.Scope, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		if !scope.Valid() {
+			return fmt.Errorf("%w: unknown scope %q", ErrInvalidToken, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	token.Scopes = scopes
+
+	return nil
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/mail"
+	"slices"
+	"time"
 
 	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
 	"github.com/moremoneymod/pr-reviewer/internal/repository"
 	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
 )
 
+// SetIsActive (de)activates the user. Users may change themselves; admins
+// and team leads their teammates.
 func (s *Service) SetIsActive(ctx context.Context, userId string, isActive bool) (*domain.User, error) {
 	const op = "internal.service.user.SetIsActive"
 
@@ -18,6 +23,11 @@ func (s *Service) SetIsActive(ctx context.Context, userId string, isActive bool)
 		slog.String("op", op),
 		slog.String("userId", userId))
 
+	if err := s.authorizeTeammate(ctx, userId); err != nil {
+		log.Warn("caller may not change user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Info("attempting to set user active flag")
 	user, err := s.UserProvider.SetIsActive(ctx, userId, isActive)
 	if errors.Is(err, repository.ErrUserNotFound) {
@@ -33,6 +43,213 @@ func (s *Service) SetIsActive(ctx context.Context, userId string, isActive bool)
 	return user, nil
 }
 
+// SetRole makes the user an admin or a member. Only admins may change roles.
+func (s *Service) SetRole(ctx context.Context, userId string, role domain.Role) (*domain.User, error) {
+	const op = "internal.service.user.SetRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId),
+		slog.String("role", string(role)))
+
+	if role != domain.RoleAdmin && role != domain.RoleMember {
+		log.Warn("invalid role")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidRole)
+	}
+	if err := s.authorizeAdmin(ctx); err != nil {
+		log.Warn("caller may not change roles", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to set user role")
+	user, err := s.UserProvider.SetRole(ctx, userId, role)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to set user role", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully set user role")
+	return user, nil
+}
+
+// SetTeamRole makes the user a lead or a plain member of one of their
+// teams. Admins and leads of the team may change team roles.
+func (s *Service) SetTeamRole(
+	ctx context.Context,
+	userId string,
+	teamName string,
+	role domain.Role,
+) (*domain.User, error) {
+	const op = "internal.service.user.SetTeamRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId),
+		slog.String("teamName", teamName),
+		slog.String("role", string(role)))
+
+	if role != domain.RoleTeamLead && role != domain.RoleMember {
+		log.Warn("invalid team role")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidRole)
+	}
+
+	log.Info("attempting to get team")
+	team, err := s.TeamProvider.GetTeam(ctx, teamName)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		log.Warn("team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.authorizeLead(ctx, team.ID); err != nil {
+		log.Warn("caller may not change team roles", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to set team role")
+	user, err := s.UserProvider.SetTeamRole(ctx, userId, team.ID, role)
+	if errors.Is(err, repository.ErrNotTeamMember) {
+		log.Warn("user is not a team member")
+		return nil, fmt.Errorf("%s: %w", op, ErrNotTeamMember)
+	}
+	if err != nil {
+		log.Error("failed to set team role", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully set team role")
+	return user, nil
+}
+
+func (s *Service) GetUser(ctx context.Context, userId string) (*domain.User, error) {
+	const op = "internal.service.user.GetUser"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId))
+
+	log.Info("attempting to get user")
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully got user")
+	return user, nil
+}
+
+func (s *Service) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	const op = "internal.service.user.ListUsers"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", filter.TeamName))
+
+	log.Info("attempting to list users")
+	users, err := s.UserProvider.ListUsers(ctx, filter)
+	if err != nil {
+		log.Error("failed to list users", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully listed users", slog.Int("count", len(users)))
+	return users, nil
+}
+
+// SetWorkingHours sets when the user takes reviews. Users may change
+// themselves; admins and team leads their teammates.
+func (s *Service) SetWorkingHours(
+	ctx context.Context,
+	userId string,
+	workingHours domain.WorkingHours,
+) (*domain.User, error) {
+	const op = "internal.service.user.SetWorkingHours"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId))
+
+	if err := s.authorizeTeammate(ctx, userId); err != nil {
+		log.Warn("caller may not change user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := time.LoadLocation(workingHours.Timezone); err != nil {
+		log.Warn("invalid timezone", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidTimezone)
+	}
+	if workingHours.StartHour < 0 || workingHours.StartHour > 23 ||
+		workingHours.EndHour < 1 || workingHours.EndHour > 24 ||
+		workingHours.StartHour == workingHours.EndHour {
+		log.Warn("invalid working hours")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidHours)
+	}
+
+	log.Info("attempting to set user working hours")
+	user, err := s.UserProvider.SetWorkingHours(ctx, userId, workingHours)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to set working hours", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully set user working hours")
+	return user, nil
+}
+
+// SetEmail sets the email the review digest is sent to and whether the user
+// wants it. Users may change themselves; admins and team leads their
+// teammates.
+func (s *Service) SetEmail(ctx context.Context, userId string, settings domain.EmailSettings) (*domain.User, error) {
+	const op = "internal.service.user.SetEmail"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId))
+
+	if err := s.authorizeTeammate(ctx, userId); err != nil {
+		log.Warn("caller may not change user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if settings.Email != "" {
+		address, err := mail.ParseAddress(settings.Email)
+		if err != nil || address.Name != "" {
+			log.Warn("invalid email")
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidEmail)
+		}
+	}
+
+	log.Info("attempting to set user email")
+	user, err := s.UserProvider.SetEmail(ctx, userId, settings)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to set email", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("successfully set user email")
+	return user, nil
+}
+
 func (s *Service) GetReview(ctx context.Context, userId string) ([]*domain.PRShort, error) {
 	const op = "internal.service.getReview"
 
@@ -40,13 +257,19 @@ func (s *Service) GetReview(ctx context.Context, userId string) ([]*domain.PRSho
 		slog.String("op", op),
 		slog.String("userId", userId))
 
-	prIds, err := s.PRRepository.GetPullRequestsIdsByReviewer(ctx, userId)
+	log.Info("attempting to get user")
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
 	if err != nil {
-		log.Error("failed to get review", sl.Err(err))
+		log.Error("failed to get user", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
 	log.Info("attempting to get review")
-	prs, err := s.UserProvider.GetReview(ctx, prIds)
+	prs, err := s.reviewsWithWait(ctx, userId, user.WorkingHours, time.Now())
 	if err != nil {
 		log.Error("failed to get review", sl.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -55,3 +278,210 @@ func (s *Service) GetReview(ctx context.Context, userId string) ([]*domain.PRSho
 	log.Info("successfully got review")
 	return prs, nil
 }
+
+// reviewsWithWait returns the user's reviews with how long the open ones
+// have waited in the user's working hours and whether they are overdue.
+func (s *Service) reviewsWithWait(
+	ctx context.Context,
+	userId string,
+	workingHours domain.WorkingHours,
+	now time.Time,
+) ([]*domain.PRShort, error) {
+	const op = "internal.service.user.reviewsWithWait"
+
+	prs, err := s.UserProvider.GetReview(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slas := make(map[int]time.Duration)
+	for _, pr := range prs {
+		if pr.AssignedAt == nil || pr.Status != "OPEN" {
+			continue
+		}
+
+		sla, ok := slas[pr.TeamID]
+		if !ok {
+			sla, err = s.teamReviewSLA(ctx, pr.TeamID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			slas[pr.TeamID] = sla
+		}
+
+		pr.BusinessWait = workingHours.BusinessDuration(*pr.AssignedAt, now)
+		pr.Overdue = sla > 0 && pr.BusinessWait > sla
+	}
+
+	return prs, nil
+}
+
+// MoveTeam moves the user from one team to another and handles their open
+// reviews on the old team's PRs according to the policy. fromTeamName may be
+// empty when the user belongs to at most one team. With the reassign policy
+// a new reviewer is picked for every open review up front, and the reviews
+// are handed over in the same transaction as the move; reviews without a
+// candidate are kept. Users must be admins or lead one of the two teams.
+func (s *Service) MoveTeam(
+	ctx context.Context,
+	userId string,
+	fromTeamName string,
+	teamName string,
+	policy domain.OpenReviewsPolicy,
+) (*domain.TeamMove, error) {
+	const op = "internal.service.user.MoveTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userId", userId),
+		slog.String("fromTeamName", fromTeamName),
+		slog.String("teamName", teamName))
+
+	if policy == "" {
+		policy = domain.OpenReviewsReject
+	}
+	if policy != domain.OpenReviewsReassign {
+		if _, err := normalizeOpenReviewsPolicy(policy); err != nil {
+			log.Warn("invalid open reviews policy")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Info("attempting to get user")
+	user, err := s.UserProvider.GetUser(ctx, userId)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		log.Warn("user not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("attempting to get team")
+	toTeam, err := s.TeamProvider.GetTeam(ctx, teamName)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		log.Warn("team not found")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamNotFound)
+	}
+	if err != nil {
+		log.Error("failed to get team", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if user.InTeam(toTeam.ID) {
+		log.Warn("user already in team")
+		return nil, fmt.Errorf("%s: %w", op, ErrAlreadyInTeam)
+	}
+
+	var from domain.TeamMembership
+	switch {
+	case fromTeamName != "":
+		idx := slices.IndexFunc(user.Teams, func(membership domain.TeamMembership) bool {
+			return membership.TeamName == fromTeamName
+		})
+		if idx < 0 {
+			log.Warn("user is not a member of the source team")
+			return nil, fmt.Errorf("%s: %w", op, ErrNotTeamMember)
+		}
+		from = user.Teams[idx]
+	case len(user.Teams) == 1:
+		from = user.Teams[0]
+	case len(user.Teams) > 1:
+		log.Warn("source team is required")
+		return nil, fmt.Errorf("%s: %w", op, ErrTeamRequired)
+	}
+
+	if err := s.authorizeLead(ctx, from.TeamID, toTeam.ID); err != nil {
+		log.Warn("caller may not move user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	move := &domain.TeamMove{
+		UserID:   userId,
+		FromTeam: from.TeamName,
+		ToTeam:   toTeam.Name,
+		Policy:   policy,
+	}
+
+	log.Info("attempting to get open reviews")
+	reviews, err := s.UserProvider.GetReview(ctx, userId)
+	if err != nil {
+		log.Error("failed to get reviews", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var openReviews []string
+	for _, review := range reviews {
+		if from.TeamID != 0 && review.Status == "OPEN" && review.TeamID == from.TeamID {
+			openReviews = append(openReviews, review.ID)
+		}
+	}
+
+	var (
+		plans        []*reassignPlan
+		replacements []domain.ReviewerReplacement
+	)
+	storagePolicy := policy
+	switch policy {
+	case domain.OpenReviewsReject:
+		if len(openReviews) > 0 {
+			log.Warn("user has open reviews")
+			return nil, fmt.Errorf("%s: %w", op, ErrOpenReviews)
+		}
+	case domain.OpenReviewsUnassign:
+		move.UnassignedReviews = openReviews
+	case domain.OpenReviewsKeep:
+		move.KeptReviews = openReviews
+	case domain.OpenReviewsReassign:
+		storagePolicy = domain.OpenReviewsKeep
+		for _, prId := range openReviews {
+			if err := s.authorizeReassign(ctx, prId, userId); err != nil {
+				log.Warn("caller may not reassign review", slog.String("prId", prId), sl.Err(err))
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			plan, err := s.planReassign(ctx, prId, userId, domain.UnassignReasonMovedTeam)
+			if errors.Is(err, ErrNoCandidates) {
+				move.KeptReviews = append(move.KeptReviews, prId)
+				continue
+			}
+			if err != nil {
+				log.Error("failed to pick a new reviewer", slog.String("prId", prId), sl.Err(err))
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			plans = append(plans, plan)
+			replacements = append(replacements, plan.replacement)
+			move.ReassignedReviews = append(move.ReassignedReviews, domain.Reassignment{
+				PRID:          prId,
+				NewReviewerID: plan.replacement.NewReviewerID,
+			})
+		}
+	}
+
+	log.Info("attempting to move user")
+	err = s.UserProvider.MoveUser(ctx, userId, from.TeamID, toTeam.ID, storagePolicy, replacements)
+	if errors.Is(err, repository.ErrOpenReviews) {
+		log.Warn("user has open reviews")
+		return nil, fmt.Errorf("%s: %w", op, ErrOpenReviews)
+	}
+	if errors.Is(err, repository.ErrNotReviewer) {
+		log.Warn("user is no longer a reviewer of a reassigned PR")
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotReviewer)
+	}
+	if errors.Is(err, repository.ErrAlreadyReviewer) {
+		log.Warn("new reviewer was assigned concurrently")
+		return nil, fmt.Errorf("%s: %w", op, ErrAlreadyReviewer)
+	}
+	if err != nil {
+		log.Error("failed to move user", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, plan := range plans {
+		s.announceReassign(ctx, plan)
+	}
+
+	log.Info("successfully moved user")
+	return move, nil
+}
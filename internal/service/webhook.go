@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/moremoneymod/pr-reviewer/internal/lib/logger/sl"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+// HandleVCSEvent applies a normalized pull request event from a VCS
+// provider. Each delivery is applied once: a redelivery with the same ID
+// reports a duplicate, and a delivery that fails is forgotten so that the
+// provider's retry is applied again. Events that cannot be applied, such as
+// drafts, unmapped authors or PRs registered before the integration, are
+// ignored with a reason.
+func (s *Service) HandleVCSEvent(ctx context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	const op = "internal.service.webhook.HandleVCSEvent"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("provider", event.Provider),
+		slog.String("deliveryId", event.DeliveryID),
+		slog.String("action", string(event.Action)),
+		slog.String("prId", event.PRID))
+
+	claimed, err := s.claimDelivery(ctx, event.Provider, event.DeliveryID)
+	if err != nil {
+		log.Error("failed to claim delivery", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !claimed {
+		log.Info("duplicate delivery")
+		return &domain.VCSEventResult{Outcome: domain.VCSOutcomeDuplicate}, nil
+	}
+
+	result, err := s.applyVCSEvent(ctx, event)
+	if err != nil {
+		if releaseErr := s.releaseDelivery(ctx, event.Provider, event.DeliveryID); releaseErr != nil {
+			log.Error("failed to release delivery", sl.Err(releaseErr))
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("applied vcs event", slog.String("outcome", string(result.Outcome)), slog.String("reason", result.Reason))
+	return result, nil
+}
+
+func (s *Service) applyVCSEvent(ctx context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	switch event.Action {
+	case domain.VCSActionOpened:
+		return s.createFromVCSEvent(ctx, event)
+	case domain.VCSActionReopened:
+		_, err := s.Reopen(ctx, event.PRID)
+		if errors.Is(err, ErrPRNotFound) {
+			return s.createFromVCSEvent(ctx, event)
+		}
+		if errors.Is(err, ErrPRMerged) {
+			return ignored("pull request is merged"), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.VCSEventResult{Outcome: domain.VCSOutcomeReopened}, nil
+	case domain.VCSActionMerged:
+		_, err := s.Merge(ctx, event.PRID)
+		if errors.Is(err, ErrPRNotFound) {
+			return ignored("unknown pull request"), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.VCSEventResult{Outcome: domain.VCSOutcomeMerged}, nil
+	case domain.VCSActionClosed:
+		_, err := s.Close(ctx, event.PRID)
+		if errors.Is(err, ErrPRNotFound) {
+			return ignored("unknown pull request"), nil
+		}
+		if errors.Is(err, ErrPRMerged) {
+			return ignored("pull request is merged"), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.VCSEventResult{Outcome: domain.VCSOutcomeClosed}, nil
+	default:
+		return ignored("unsupported action"), nil
+	}
+}
+
+// createFromVCSEvent registers the PR once it is ready for review. Drafts are
+// skipped until the provider reports them ready.
+func (s *Service) createFromVCSEvent(ctx context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	if event.Draft {
+		return ignored("draft pull request"), nil
+	}
+	if event.AuthorID == "" {
+		return ignored(fmt.Sprintf("unknown %s author %s", event.Provider, event.AuthorLogin)), nil
+	}
+
+	_, err := s.createPR(ctx, domain.PR{
+		ID:       event.PRID,
+		Name:     event.Title,
+		AuthorID: event.AuthorID,
+		Source: &domain.PRSource{
+			Provider:   event.Provider,
+			Repository: event.Repository,
+			Number:     event.Number,
+		},
+	}, "")
+	if errors.Is(err, ErrPRExists) {
+		return &domain.VCSEventResult{Outcome: domain.VCSOutcomeExists}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.VCSEventResult{Outcome: domain.VCSOutcomeCreated}, nil
+}
+
+func ignored(reason string) *domain.VCSEventResult {
+	return &domain.VCSEventResult{Outcome: domain.VCSOutcomeIgnored, Reason: reason}
+}
+
+// claimDelivery reports whether a webhook delivery is seen for the first
+// time. Deliveries without an ID cannot be deduplicated and always count as
+// new.
+func (s *Service) claimDelivery(ctx context.Context, provider string, deliveryId string) (bool, error) {
+	const op = "internal.service.webhook.claimDelivery"
+
+	if deliveryId == "" {
+		return true, nil
+	}
+
+	claimed, err := s.WebhookProvider.ClaimDelivery(ctx, provider, deliveryId)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claimed, nil
+}
+
+func (s *Service) releaseDelivery(ctx context.Context, provider string, deliveryId string) error {
+	const op = "internal.service.webhook.releaseDelivery"
+
+	if deliveryId == "" {
+		return nil
+	}
+
+	err := s.WebhookProvider.ReleaseDelivery(ctx, provider, deliveryId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/gitea"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/github"
+	"github.com/moremoneymod/pr-reviewer/internal/api/http/handlers/webhooks/gitlab"
+	"github.com/moremoneymod/pr-reviewer/internal/config"
+	"github.com/moremoneymod/pr-reviewer/internal/lib/signature"
+	"github.com/moremoneymod/pr-reviewer/internal/service"
+	"github.com/moremoneymod/pr-reviewer/internal/service/domain"
+)
+
+const fixtures = "../api/http/handlers/webhooks"
+
+// recorder keeps the events the adapters hand to the service.
+type recorder struct {
+	*service.Service
+	events []domain.VCSEvent
+}
+
+func (r *recorder) HandleVCSEvent(ctx context.Context, event domain.VCSEvent) (*domain.VCSEventResult, error) {
+	r.events = append(r.events, event)
+	return r.Service.HandleVCSEvent(ctx, event)
+}
+
+type providerCase struct {
+	name     string
+	env      map[string]string
+	fixtures []string
+	prId     string
+	source   domain.PRSource
+	newFunc  func(log *slog.Logger, handler *recorder) (http.HandlerFunc, error)
+	headers  func(deliveryId string, body []byte) map[string]string
+}
+
+var providers = []providerCase{
+	{
+		name: "github",
+		env:  map[string]string{"GITHUB_WEBHOOK_SECRET": "secret", "GITHUB_USER_MAPPING": "octocat=u1"},
+		fixtures: []string{
+			"pull_request_opened_draft", "pull_request_opened", "pull_request_closed",
+			"pull_request_reopened", "pull_request_merged",
+		},
+		prId:   "github-2023456789",
+		source: domain.PRSource{Provider: "github", Repository: "acme/pr-reviewer", Number: 42},
+		newFunc: func(log *slog.Logger, handler *recorder) (http.HandlerFunc, error) {
+			cfg, err := config.NewGitHubConfig()
+			return github.New(log, cfg, handler), err
+		},
+		headers: func(deliveryId string, body []byte) map[string]string {
+			return map[string]string{
+				"X-GitHub-Event":      "pull_request",
+				"X-GitHub-Delivery":   deliveryId,
+				"X-Hub-Signature-256": "sha256=" + signature.SignSHA256("secret", body),
+			}
+		},
+	},
+	{
+		name: "gitlab",
+		env:  map[string]string{"GITLAB_WEBHOOK_TOKEN": "secret", "GITLAB_USER_MAPPING": "alice=u1"},
+		fixtures: []string{
+			"merge_request_open_draft", "merge_request_open", "merge_request_close",
+			"merge_request_reopen", "merge_request_merge",
+		},
+		prId:   "gitlab-90311",
+		source: domain.PRSource{Provider: "gitlab", Repository: "platform/billing", Number: 57},
+		newFunc: func(log *slog.Logger, handler *recorder) (http.HandlerFunc, error) {
+			cfg, err := config.NewGitLabConfig()
+			return gitlab.New(log, cfg, handler), err
+		},
+		headers: func(deliveryId string, _ []byte) map[string]string {
+			return map[string]string{
+				"X-Gitlab-Event":      "Merge Request Hook",
+				"X-Gitlab-Event-UUID": deliveryId,
+				"X-Gitlab-Token":      "secret",
+			}
+		},
+	},
+	{
+		name: "gitea",
+		env:  map[string]string{"GITEA_WEBHOOK_SECRET": "secret", "GITEA_USER_MAPPING": "alice=u1"},
+		fixtures: []string{
+			"pull_request_opened_wip", "pull_request_opened", "pull_request_closed",
+			"pull_request_reopened", "pull_request_merged",
+		},
+		prId:   "gitea-3821",
+		source: domain.PRSource{Provider: "gitea", Repository: "platform/billing", Number: 17},
+		newFunc: func(log *slog.Logger, handler *recorder) (http.HandlerFunc, error) {
+			cfg, err := config.NewGiteaConfig()
+			return gitea.New(log, cfg, handler), err
+		},
+		headers: func(deliveryId string, body []byte) map[string]string {
+			return map[string]string{
+				"X-Gitea-Event":     "pull_request",
+				"X-Gitea-Delivery":  deliveryId,
+				"X-Gitea-Signature": signature.SignSHA256("secret", body),
+			}
+		},
+	},
+}
+
+// TestHandleVCSEventAcrossProviders replays the same pull request lifecycle
+// recorded from each provider: a draft, then ready for review, closed,
+// reopened and merged. Every provider must yield the same normalized events
+// and leave the PR in the same state.
+func TestHandleVCSEventAcrossProviders(t *testing.T) {
+	wantActions := []domain.VCSAction{
+		domain.VCSActionOpened, domain.VCSActionOpened, domain.VCSActionClosed,
+		domain.VCSActionReopened, domain.VCSActionMerged,
+	}
+	wantOutcomes := []domain.VCSOutcome{
+		domain.VCSOutcomeIgnored, domain.VCSOutcomeCreated, domain.VCSOutcomeClosed,
+		domain.VCSOutcomeReopened, domain.VCSOutcomeMerged,
+	}
+
+	for _, p := range providers {
+		t.Run(p.name, func(t *testing.T) {
+			for key, value := range p.env {
+				t.Setenv(key, value)
+			}
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+			prs := &fakePRs{prs: map[string]domain.PR{}}
+			providers := service.Providers{
+				PR:      prs,
+				Team:    fakeTeams{},
+				User:    fakeUsers{},
+				Webhook: &fakeWebhooks{claimed: map[string]bool{}},
+			}
+			svc := service.New(log, providers, service.Clients{}, reviewerConfig{})
+			handler := &recorder{Service: svc}
+			serve, err := p.newFunc(log, handler)
+			if err != nil {
+				t.Fatalf("config: %v", err)
+			}
+
+			for i, fixture := range p.fixtures {
+				body, err := os.ReadFile(filepath.Join(fixtures, p.name, "testdata", fixture+".json"))
+				if err != nil {
+					t.Fatalf("read fixture: %v", err)
+				}
+
+				req := httptest.NewRequest(http.MethodPost, "/webhooks/"+p.name, bytes.NewReader(body))
+				for key, value := range p.headers(fmt.Sprintf("delivery-%d", i), body) {
+					req.Header.Set(key, value)
+				}
+				rec := httptest.NewRecorder()
+				serve.ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("%s: status = %d: %s", fixture, rec.Code, rec.Body)
+				}
+				if !bytes.Contains(rec.Body.Bytes(), []byte(`"result":"`+string(wantOutcomes[i])+`"`)) {
+					t.Errorf("%s: response = %s, want result %q", fixture, rec.Body, wantOutcomes[i])
+				}
+			}
+
+			if len(handler.events) != len(wantActions) {
+				t.Fatalf("handled %d events, want %d", len(handler.events), len(wantActions))
+			}
+			for i, event := range handler.events {
+				if event.Provider != p.name || event.PRID != p.prId || event.Action != wantActions[i] {
+					t.Errorf("event %d = %+v, want %s %s %s", i, event, p.name, p.prId, wantActions[i])
+				}
+				if event.Draft != (i == 0) {
+					t.Errorf("event %d draft = %t", i, event.Draft)
+				}
+				if event.Repository != p.source.Repository || event.Number != p.source.Number {
+					t.Errorf("event %d locates %s#%d, want %s#%d",
+						i, event.Repository, event.Number, p.source.Repository, p.source.Number)
+				}
+			}
+
+			pr, ok := prs.prs[p.prId]
+			if !ok {
+				t.Fatalf("pr %s was not created", p.prId)
+			}
+			if pr.AuthorID != "u1" || pr.TeamID != platform.ID || pr.Status != domain.PRStatusMerged {
+				t.Errorf("pr = %+v", pr)
+			}
+			if pr.Source == nil || *pr.Source != p.source {
+				t.Errorf("pr source = %+v, want %+v", pr.Source, p.source)
+			}
+			if len(pr.Reviewers) != 1 || pr.Reviewers[0] != "u2" {
+				t.Errorf("pr reviewers = %v, want [u2]", pr.Reviewers)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE assignment_logs (
+                                 id BIGSERIAL PRIMARY KEY,
+                                 pr_id VARCHAR(50) REFERENCES pull_requests(id) ON DELETE CASCADE,
+                                 trigger VARCHAR(20) NOT NULL,
+                                 strategy VARCHAR(50) NOT NULL,
+                                 seed BIGINT NOT NULL,
+                                 candidates JSONB NOT NULL DEFAULT '[]',
+                                 exclusions JSONB NOT NULL DEFAULT '[]',
+                                 scores JSONB NOT NULL DEFAULT '[]',
+                                 chosen JSONB NOT NULL DEFAULT '[]',
+                                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX assignment_logs_pr_id_idx ON assignment_logs (pr_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE assignment_logs;
+-- +goose StatementEnd
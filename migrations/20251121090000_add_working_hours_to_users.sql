@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN work_start SMALLINT NOT NULL DEFAULT 9 CHECK (work_start BETWEEN 0 AND 23),
+    ADD COLUMN work_end SMALLINT NOT NULL DEFAULT 18 CHECK (work_end BETWEEN 1 AND 24);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN timezone,
+    DROP COLUMN work_start,
+    DROP COLUMN work_end;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_members (
+                              team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,
+                              user_id VARCHAR(50) REFERENCES users(id) ON DELETE CASCADE,
+                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX team_members_user_id_idx ON team_members (user_id);
+
+INSERT INTO team_members (team_id, user_id)
+SELECT team_id, id FROM users WHERE team_id IS NOT NULL;
+
+ALTER TABLE pull_requests ADD COLUMN team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_id = u.team_id
+FROM users u
+WHERE u.id = pr.author_id;
+
+ALTER TABLE users DROP COLUMN team_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
+
+UPDATE users u
+SET team_id = tm.team_id
+FROM (SELECT user_id, MIN(team_id) AS team_id FROM team_members GROUP BY user_id) tm
+WHERE tm.user_id = u.id;
+
+ALTER TABLE pull_requests DROP COLUMN team_id;
+
+DROP TABLE team_members;
+-- +goose StatementEnd
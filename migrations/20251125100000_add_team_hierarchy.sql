@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ADD COLUMN parent_id INTEGER REFERENCES teams(id) ON DELETE SET NULL,
+    ADD COLUMN review_sla_minutes INTEGER NULL CHECK (review_sla_minutes > 0),
+    ADD COLUMN reviewer_fallback VARCHAR(20) NULL CHECK (reviewer_fallback IN ('none', 'parent'));
+
+CREATE INDEX teams_parent_id_idx ON teams (parent_id);
+
+ALTER TABLE assignment_logs ADD COLUMN team_id INTEGER REFERENCES teams(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE assignment_logs DROP COLUMN team_id;
+
+ALTER TABLE teams
+    DROP COLUMN parent_id,
+    DROP COLUMN review_sla_minutes,
+    DROP COLUMN reviewer_fallback;
+-- +goose StatementEnd
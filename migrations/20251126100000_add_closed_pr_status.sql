@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED')),
+    ADD COLUMN closed_at TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests
+    DROP COLUMN closed_at,
+    DROP CONSTRAINT pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED'));
+-- +goose StatementEnd
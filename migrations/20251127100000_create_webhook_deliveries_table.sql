@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhook_deliveries (
+                                    provider VARCHAR(20) NOT NULL,
+                                    delivery_id VARCHAR(100) NOT NULL,
+                                    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                    PRIMARY KEY (provider, delivery_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    ADD COLUMN source_provider VARCHAR(20),
+    ADD COLUMN source_repository VARCHAR(255),
+    ADD COLUMN source_number INTEGER;
+
+CREATE TABLE review_request_queue (
+                                      id BIGSERIAL PRIMARY KEY,
+                                      pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                                      reviewers TEXT[] NOT NULL DEFAULT '{}',
+                                      removed TEXT[] NOT NULL DEFAULT '{}',
+                                      attempts INTEGER NOT NULL DEFAULT 0,
+                                      last_error TEXT,
+                                      next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                      failed_at TIMESTAMP,
+                                      created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX review_request_queue_due_idx ON review_request_queue (next_attempt_at) WHERE failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE review_request_queue;
+
+ALTER TABLE pull_requests
+    DROP COLUMN source_provider,
+    DROP COLUMN source_repository,
+    DROP COLUMN source_number;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox_events (
+                               id BIGSERIAL PRIMARY KEY,
+                               event_type VARCHAR(50) NOT NULL,
+                               pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                               payload JSONB NOT NULL,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               dispatched_at TIMESTAMP
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (id) WHERE dispatched_at IS NULL;
+
+CREATE TABLE subscriptions (
+                               id BIGSERIAL PRIMARY KEY,
+                               url TEXT NOT NULL,
+                               secret VARCHAR(255) NOT NULL,
+                               event_types TEXT[] NOT NULL,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE subscription_deliveries (
+                                         id BIGSERIAL PRIMARY KEY,
+                                         subscription_id BIGINT NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+                                         event_id BIGINT NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+                                         status VARCHAR(20) NOT NULL DEFAULT 'pending',
+                                         attempts INTEGER NOT NULL DEFAULT 0,
+                                         last_status_code INTEGER,
+                                         last_error TEXT,
+                                         next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                         delivered_at TIMESTAMP,
+                                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                         UNIQUE (subscription_id, event_id),
+                                         CONSTRAINT subscription_deliveries_status_check CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX subscription_deliveries_due_idx ON subscription_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX subscription_deliveries_subscription_id_idx ON subscription_deliveries (subscription_id, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE subscription_deliveries;
+DROP TABLE subscriptions;
+DROP TABLE outbox_events;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr_reviewers
+    ADD COLUMN overdue_notified_at TIMESTAMP;
+
+CREATE TABLE notification_queue (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    kind VARCHAR(20) NOT NULL,
+                                    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                                    pr_name VARCHAR(255) NOT NULL,
+                                    team_name VARCHAR(255) NOT NULL DEFAULT '',
+                                    user_id VARCHAR(50) NOT NULL,
+                                    replaced_user_id VARCHAR(50) NOT NULL DEFAULT '',
+                                    wait_seconds BIGINT NOT NULL DEFAULT 0,
+                                    attempts INTEGER NOT NULL DEFAULT 0,
+                                    last_error TEXT,
+                                    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    failed_at TIMESTAMP,
+                                    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notification_queue_due_idx ON notification_queue (next_attempt_at) WHERE failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_queue;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN overdue_notified_at;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN email VARCHAR(255),
+    ADD COLUMN digest_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN digest_sent_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN email,
+    DROP COLUMN digest_enabled,
+    DROP COLUMN digest_sent_at;
+-- +goose StatementEnd
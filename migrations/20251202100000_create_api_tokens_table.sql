@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE api_tokens (
+                            id BIGSERIAL PRIMARY KEY,
+                            name VARCHAR(255) NOT NULL,
+                            token_hash CHAR(64) UNIQUE NOT NULL,
+                            prefix VARCHAR(16) NOT NULL,
+                            scopes TEXT[] NOT NULL,
+                            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                            last_used_at TIMESTAMP,
+                            revoked_at TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE api_tokens;
+-- +goose StatementEnd
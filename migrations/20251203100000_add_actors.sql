@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    ADD COLUMN merged_by VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE assignment_logs
+    ADD COLUMN actor_id VARCHAR(50) REFERENCES users(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE assignment_logs
+    DROP COLUMN actor_id;
+
+ALTER TABLE pull_requests
+    DROP COLUMN merged_by;
+-- +goose StatementEnd
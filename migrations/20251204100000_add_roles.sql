@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member'));
+
+ALTER TABLE team_members
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('team_lead', 'member'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_members
+    DROP COLUMN role;
+
+ALTER TABLE users
+    DROP COLUMN role;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_events (
+                              id BIGSERIAL PRIMARY KEY,
+                              actor VARCHAR(255) NOT NULL,
+                              action VARCHAR(50) NOT NULL,
+                              entity_type VARCHAR(20) NOT NULL,
+                              entity_id VARCHAR(255) NOT NULL,
+                              diff JSONB NOT NULL,
+                              request_id VARCHAR(100),
+                              created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX audit_events_entity_idx ON audit_events (entity_type, entity_id, created_at);
+CREATE INDEX audit_events_actor_idx ON audit_events (actor, created_at);
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+
+CREATE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_events;
+DROP FUNCTION audit_events_append_only();
+-- +goose StatementEnd
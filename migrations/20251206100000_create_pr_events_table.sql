@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_events (
+                           id BIGSERIAL PRIMARY KEY,
+                           pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+                           type VARCHAR(30) NOT NULL,
+                           actor VARCHAR(255) NOT NULL,
+                           user_id VARCHAR(50),
+                           reason VARCHAR(30),
+                           decision VARCHAR(30),
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                           CONSTRAINT pr_events_type_check CHECK (type IN (
+                               'created', 'reviewer_assigned', 'reviewer_removed', 'reviewed', 'merged', 'closed', 'reopened'
+                           )),
+                           CONSTRAINT pr_events_decision_check CHECK (decision IN ('approved', 'changes_requested', 'commented'))
+);
+
+CREATE INDEX pr_events_pr_id_idx ON pr_events (pr_id, created_at, id);
+
+INSERT INTO pr_events (pr_id, type, actor, created_at)
+SELECT id, 'created', 'system', COALESCE(created_at, NOW())
+FROM pull_requests;
+
+INSERT INTO pr_events (pr_id, type, actor, user_id, created_at)
+SELECT pr_id, 'reviewer_assigned', 'system', user_id, COALESCE(assigned_at, NOW())
+FROM pr_reviewers;
+
+INSERT INTO pr_events (pr_id, type, actor, created_at)
+SELECT id, 'merged', COALESCE(merged_by, 'system'), merged_at
+FROM pull_requests
+WHERE merged_at IS NOT NULL;
+
+INSERT INTO pr_events (pr_id, type, actor, created_at)
+SELECT id, 'closed', 'system', closed_at
+FROM pull_requests
+WHERE closed_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE pr_events;
+-- +goose StatementEnd
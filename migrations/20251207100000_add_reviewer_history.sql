@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT pr_reviewers_pkey,
+    ADD COLUMN id BIGSERIAL PRIMARY KEY,
+    ADD COLUMN unassigned_at TIMESTAMP,
+    ADD COLUMN unassign_reason VARCHAR(30);
+
+CREATE UNIQUE INDEX pr_reviewers_active_idx ON pr_reviewers (pr_id, user_id) WHERE unassigned_at IS NULL;
+CREATE INDEX pr_reviewers_active_user_id_idx ON pr_reviewers (user_id) WHERE unassigned_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM pr_reviewers WHERE unassigned_at IS NOT NULL;
+
+DROP INDEX pr_reviewers_active_user_id_idx;
+DROP INDEX pr_reviewers_active_idx;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN unassign_reason,
+    DROP COLUMN unassigned_at,
+    DROP COLUMN id,
+    ADD PRIMARY KEY (pr_id, user_id);
+-- +goose StatementEnd